@@ -1,6 +1,7 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"path"
@@ -12,14 +13,200 @@ import (
 	"github.com/TrixiS/pm0/internal/utils"
 	"github.com/asdine/storm/v3"
 	"github.com/urfave/cli/v2"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-const (
-	cliClientDBFilename     = "pm0_cli.db"
-	maxRecvMessageSizeBytes = 8 * 1024 * 1024
-)
+const cliClientDBFilename = "pm0_cli.db"
+
+// startFlags are shared by start and template add
+var startFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:     "name",
+		Required: false,
+	},
+	&cli.StringFlag{
+		Name:     "cwd",
+		Required: false,
+	},
+	&cli.StringSliceFlag{
+		Name:     "env",
+		Required: false,
+		Aliases:  []string{"e"},
+		Usage:    "K=V env var of the unit, K=@file:<path> reads the value from the file on start",
+	},
+	&cli.StringSliceFlag{
+		Name:     "env-file",
+		Required: false,
+		Usage:    "dotenv file read on every start of the unit",
+	},
+	&cli.StringSliceFlag{
+		Name:     "label",
+		Required: false,
+		Aliases:  []string{"l"},
+		Usage:    "key=value label, units can be selected by key=value",
+	},
+	&cli.StringFlag{
+		Name:     "env-mode",
+		Required: false,
+		Usage:    "inherited daemon env: none, daemon or allow (defaults to the daemon config)",
+	},
+	&cli.StringSliceFlag{
+		Name:     "env-allow",
+		Required: false,
+		Usage:    "daemon env var names or globs inherited in the allow env mode",
+	},
+	&cli.StringFlag{
+		Name:     "user",
+		Required: false,
+		Aliases:  []string{"u"},
+		Usage:    "run the unit as this user (name or uid)",
+	},
+	&cli.StringFlag{
+		Name:     "group",
+		Required: false,
+		Aliases:  []string{"g"},
+		Usage:    "run the unit with this primary group (name or gid)",
+	},
+	&cli.StringSliceFlag{
+		Name:     "groups",
+		Required: false,
+		Usage:    "supplementary groups of the unit (defaults to the groups of --user)",
+	},
+	&cli.StringFlag{
+		Name:     "restart-policy",
+		Required: false,
+		Usage:    "restart policy: on-failure, always or never (defaults to the daemon config)",
+	},
+	&cli.DurationFlag{
+		Name:     "restart-delay",
+		Required: false,
+		Usage:    "delay before restarting the unit (defaults to the daemon config)",
+	},
+	&cli.StringFlag{
+		Name:     "stop-signal",
+		Required: false,
+		Usage:    "signal sent to the unit process group on stop (defaults to the daemon config)",
+	},
+	&cli.DurationFlag{
+		Name:     "stop-timeout",
+		Required: false,
+		Usage:    "time to wait after the stop signal before killing the unit (defaults to the daemon config)",
+	},
+	&cli.StringFlag{
+		Name:     "health-cmd",
+		Required: false,
+		Usage:    "shell command that checks the unit health, exit code 0 means healthy",
+	},
+	&cli.DurationFlag{
+		Name:     "health-interval",
+		Required: false,
+		Usage:    "interval between health checks (defaults to the daemon config)",
+	},
+	&cli.StringSliceFlag{
+		Name:     "hook",
+		Required: false,
+		Usage:    "<phase>=<command or url> hook, phases are pre-start, post-start, pre-stop and post-exit",
+	},
+	&cli.BoolFlag{
+		Name:     "stdin",
+		Required: false,
+		Usage:    "keep a stdin pipe open for pm0 send and pm0 attach",
+	},
+	&cli.BoolFlag{
+		Name:     "tty",
+		Required: false,
+		Usage:    "run the unit in a pseudo-terminal, its input is sent with pm0 send and pm0 attach",
+	},
+	&cli.BoolFlag{
+		Name:     "strip-ansi",
+		Required: false,
+		Usage:    "strip the ANSI escape sequences of the tty output from the log",
+	},
+}
+
+// updateFlags are shared by update and clone
+var updateFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name: "name",
+	},
+	&cli.StringFlag{
+		Name: "cwd",
+	},
+	&cli.StringSliceFlag{
+		Name:    "env",
+		Aliases: []string{"e"},
+		Usage:   "K=V env var merged into the unit env, K= removes it",
+	},
+	&cli.BoolFlag{
+		Name:  "replace-env",
+		Usage: "replace the unit env by the --env vars instead of merging them",
+	},
+	&cli.StringSliceFlag{
+		Name: "env-file",
+	},
+	&cli.StringFlag{
+		Name: "env-mode",
+	},
+	&cli.StringSliceFlag{
+		Name: "env-allow",
+	},
+	&cli.StringSliceFlag{
+		Name:    "label",
+		Aliases: []string{"l"},
+		Usage:   "key=value label merged into the unit labels, key= removes it",
+	},
+	&cli.BoolFlag{
+		Name:  "replace-labels",
+		Usage: "replace the unit labels by the --label labels instead of merging them",
+	},
+	&cli.StringFlag{
+		Name:    "user",
+		Aliases: []string{"u"},
+	},
+	&cli.StringFlag{
+		Name:    "group",
+		Aliases: []string{"g"},
+	},
+	&cli.StringSliceFlag{
+		Name: "groups",
+	},
+	&cli.StringFlag{
+		Name:  "restart-policy",
+		Usage: "on-failure, always or never, empty for the daemon config",
+	},
+	&cli.DurationFlag{
+		Name: "restart-delay",
+	},
+	&cli.StringFlag{
+		Name: "stop-signal",
+	},
+	&cli.DurationFlag{
+		Name: "stop-timeout",
+	},
+	&cli.StringFlag{
+		Name: "health-cmd",
+	},
+	&cli.DurationFlag{
+		Name: "health-interval",
+	},
+	&cli.StringSliceFlag{
+		Name:  "hook",
+		Usage: "<phase>=<command or url> hook, replaces the unit hooks",
+	},
+	&cli.BoolFlag{
+		Name:  "stdin",
+		Usage: "keep a stdin pipe open, --stdin=false closes it on the next start",
+	},
+	&cli.BoolFlag{
+		Name:  "tty",
+		Usage: "run the unit in a pseudo-terminal from the next start, --tty=false turns it off",
+	},
+	&cli.BoolFlag{
+		Name:  "strip-ansi",
+		Usage: "strip the ANSI escape sequences of the tty output from the log",
+	},
+}
 
 func main() {
 	pm0Dirpath, err := utils.GetPM0Dirpath()
@@ -29,6 +216,16 @@ func main() {
 	}
 
 	dbFilepath := path.Join(pm0Dirpath, cliClientDBFilename)
+	socketFilepath, err := utils.GetSocketFilepath()
+
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var (
+		connectionOptions pm0.ConnectionOptions
+		defaultSelectors  []string
+	)
 
 	contextProvider := command.ContextProvider{
 		DBFactory: func() *storm.DB {
@@ -41,74 +238,218 @@ func main() {
 			return db
 		},
 		WithClient: func(f func(pb.ProcessServiceClient) error) error {
-			conn, err := grpc.NewClient(
-				"localhost:7777",
-				grpc.WithTransportCredentials(insecure.NewCredentials()),
-				grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMessageSizeBytes)),
-			) // TODO: get address from somewhere (env/args)
+			conn, err := pm0.Dial(connectionOptions)
 
 			if err != nil {
-				log.Fatalf("grpc dial: %v", err)
+				return &pm0.ExitError{
+					Code: pm0.ExitDaemonUnreachable,
+					Err:  fmt.Errorf("grpc dial: %w", err),
+				}
 			}
 
 			defer conn.Close()
 
 			client := pb.NewProcessServiceClient(conn)
-			return f(client)
+			err = f(client)
+
+			if status.Code(err) == codes.Unavailable {
+				return &pm0.ExitError{
+					Code: pm0.ExitDaemonUnreachable,
+					Err: fmt.Errorf(
+						"daemon at %s is unreachable: %s",
+						connectionOptions.Address,
+						status.Convert(err).Message(),
+					),
+				}
+			}
+
+			return err
+		},
+		DefaultSelectors: func() []string {
+			return defaultSelectors
 		},
 	}
 
+	// configure applies the global flags before the commands and their
+	// completions, Before doesn't run for the completions
+	configure := func(ctx *cli.Context) error {
+		outputFormat, err := pm0.ParseOutputFormat(ctx.String("output"))
+
+		if err != nil {
+			return &pm0.ExitError{Code: pm0.ExitInvalidArguments, Err: err}
+		}
+
+		pm0.SetOutputFormat(outputFormat)
+		pm0.SetColors(!ctx.Bool("no-color"))
+
+		db := contextProvider.DBFactory()
+		contextModel, err := pm0.LoadContext(db, ctx.String("context"))
+		db.Close()
+
+		if err != nil {
+			return err
+		}
+
+		connectionOptions = pm0.ConnectionOptions{Address: socketFilepath}
+
+		if contextModel != nil {
+			connectionOptions = contextModel.ConnectionOptions()
+			defaultSelectors = contextModel.Selectors
+		}
+
+		for _, flag := range []struct {
+			name  string
+			value *string
+		}{
+			{"host", &connectionOptions.Address},
+			{"tls-ca", &connectionOptions.TLSCA},
+			{"tls-cert", &connectionOptions.TLSCert},
+			{"tls-key", &connectionOptions.TLSKey},
+			{"tls-server-name", &connectionOptions.TLSServer},
+			{"token", &connectionOptions.Token},
+		} {
+			if ctx.IsSet(flag.name) {
+				*flag.value = ctx.String(flag.name)
+			}
+		}
+
+		if ctx.IsSet("tls") {
+			connectionOptions.TLS = ctx.Bool("tls")
+		}
+
+		return nil
+	}
+
+	// completes wraps the shell completions of the commands. They are best
+	// effort, the units aren't completed when the daemon is unreachable
+	completes := func(f command.CompleteFunc) cli.BashCompleteFunc {
+		complete := contextProvider.Completes(f)
+
+		return func(ctx *cli.Context) {
+			configure(ctx)
+			complete(ctx)
+		}
+	}
+
 	app := &cli.App{
 		Name:  "pm0",
 		Usage: "CLI client for PM0 daemon",
+
+		EnableBashCompletion: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "context",
+				Aliases: []string{"c"},
+				EnvVars: []string{"PM0_CONTEXT"},
+				Usage:   "named connection context, defaults to the one selected with pm0 context use",
+			},
+			&cli.StringFlag{
+				Name:    "host",
+				Aliases: []string{"H"},
+				EnvVars: []string{"PM0_HOST"},
+				Usage:   "daemon address: unix socket path or tcp host:port",
+			},
+			&cli.BoolFlag{
+				Name:    "tls",
+				EnvVars: []string{"PM0_TLS"},
+				Usage:   "connect over TLS, implied by --tls-ca and --tls-cert",
+			},
+			&cli.StringFlag{
+				Name:    "tls-ca",
+				EnvVars: []string{"PM0_TLS_CA"},
+				Usage:   "CA certificate to verify the daemon with",
+			},
+			&cli.StringFlag{
+				Name:    "tls-cert",
+				EnvVars: []string{"PM0_TLS_CERT"},
+				Usage:   "client certificate for mTLS",
+			},
+			&cli.StringFlag{
+				Name:    "tls-key",
+				EnvVars: []string{"PM0_TLS_KEY"},
+				Usage:   "client certificate key for mTLS",
+			},
+			&cli.StringFlag{
+				Name:    "tls-server-name",
+				EnvVars: []string{"PM0_TLS_SERVER_NAME"},
+				Usage:   "override the server name used to verify the daemon certificate",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				EnvVars: []string{"PM0_TOKEN"},
+				Usage:   "bearer token, requires TLS",
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				EnvVars: []string{"PM0_OUTPUT"},
+				Value:   string(pm0.OutputTable),
+				Usage:   "output format: table, wide, json or yaml",
+			},
+			&cli.BoolFlag{
+				Name:  "no-color",
+				Usage: "disable colors, also disabled by a non-empty NO_COLOR",
+			},
+		},
+		Before: configure,
 		Commands: []*cli.Command{
 			{
-				Name: "start",
-				Flags: []cli.Flag{
-					&cli.StringFlag{
-						Name:     "name",
-						Required: false,
-					},
-					&cli.StringFlag{
-						Name:     "cwd",
-						Required: false,
-					},
-					&cli.StringSliceFlag{
-						Name:     "env",
-						Required: false,
-						Aliases:  []string{"e"},
-					},
-				},
-				Usage:     "Start a unit",
-				UsageText: "command",
-				Args:      true,
-				Action:    contextProvider.Wraps(commands.Start),
+				Name:         "start",
+				Flags:        startFlags,
+				Usage:        "Start a new unit or stopped units by id",
+				UsageText:    "pm0 start [options] <bin> [args] | pm0 start <unit ids>",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Start),
+				BashComplete: completes(commands.CompleteUnits),
 			},
 			{
-				Name:    "list",
-				Aliases: []string{"ls"},
-				Usage:   "List units",
-				Args:    false,
-				Action:  contextProvider.Wraps(commands.List),
+				Name:         "list",
+				Aliases:      []string{"ls"},
+				Usage:        "List units",
+				UsageText:    "pm0 list [unit ids or name globs]",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.List),
+				BashComplete: completes(commands.CompleteUnits),
 			},
 			{
-				Name:   "stop",
-				Usage:  "Stop a unit",
-				Args:   true,
-				Action: contextProvider.Wraps(commands.Stop),
+				Name:         "stop",
+				Usage:        "Stop a unit",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Stop),
+				BashComplete: completes(commands.CompleteUnits),
 				Subcommands: []*cli.Command{
 					createAllSubcommand(contextProvider.Wraps(commands.StopAll)),
 				},
 			},
 			{
-				Name:   "restart",
-				Usage:  "Restart a unit",
-				Args:   true,
-				Action: contextProvider.Wraps(commands.Restart),
+				Name:         "restart",
+				Usage:        "Restart a unit",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Restart),
+				BashComplete: completes(commands.CompleteUnits),
 				Subcommands: []*cli.Command{
 					createAllSubcommand(contextProvider.Wraps(commands.RestartAll)),
 				},
 			},
+			{
+				Name:         "signal",
+				Usage:        "Send a signal like SIGHUP to the main process of units",
+				UsageText:    "pm0 signal [options] <unit ids> <signal>",
+				Args:         true,
+				Flags:        []cli.Flag{signalGroupFlag},
+				Action:       contextProvider.Wraps(commands.Signal),
+				BashComplete: completes(commands.CompleteSignal),
+				Subcommands: []*cli.Command{
+					{
+						Name:         "all",
+						UsageText:    "pm0 signal all [options] <signal>",
+						Flags:        append([]cli.Flag{signalGroupFlag}, allSubCommandFlags...),
+						Args:         true,
+						Action:       contextProvider.Wraps(commands.SignalAll),
+						BashComplete: completes(commands.CompleteSignalAll),
+					},
+				},
+			},
 			{
 				Name: "logs",
 				Flags: []cli.Flag{
@@ -123,57 +464,353 @@ func main() {
 						Aliases:  []string{"f"},
 					},
 				},
-				Usage:  "Show unit logfile contents",
-				Args:   true,
-				Action: contextProvider.Wraps(commands.Logs),
+				Usage:        "Show unit logfile contents",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Logs),
+				BashComplete: completes(commands.CompleteUnit),
 				Subcommands: []*cli.Command{
 					{
-						Name:   "clear",
-						Usage:  "Clear unit log file",
-						Args:   true,
-						Action: contextProvider.Wraps(commands.LogsClear),
+						Name:         "clear",
+						Usage:        "Clear unit log file",
+						Args:         true,
+						Action:       contextProvider.Wraps(commands.LogsClear),
+						BashComplete: completes(commands.CompleteUnit),
 					},
 				},
 			},
 			{
-				Name:    "delete",
-				Usage:   "Delete units",
-				Aliases: []string{"rm"},
-				Args:    true,
-				Action:  contextProvider.Wraps(commands.Delete),
+				Name:         "delete",
+				Usage:        "Delete units",
+				Aliases:      []string{"rm"},
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Delete),
+				BashComplete: completes(commands.CompleteUnits),
 				Subcommands: []*cli.Command{
 					createAllSubcommand(contextProvider.Wraps(commands.DeleteAll)),
 				},
 			},
 			{
-				Name:   "show",
-				Usage:  "Show unit info",
-				Args:   true,
-				Action: contextProvider.Wraps(commands.Show),
+				Name:         "show",
+				Usage:        "Show unit info",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Show),
+				BashComplete: completes(commands.CompleteUnit),
+			},
+			{
+				Name: "events",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:     "json",
+						Required: false,
+						Usage:    "print one JSON object per event",
+					},
+					&cli.Uint64Flag{
+						Name:     "since",
+						Required: false,
+						Usage:    "replay the buffered events after this sequence number",
+					},
+				},
+				Usage:        "Stream unit and daemon events",
+				UsageText:    "pm0 events [options] [unit ids or name globs]",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Events),
+				BashComplete: completes(commands.CompleteUnits),
+			},
+			{
+				Name: "history",
+				Flags: []cli.Flag{
+					&cli.UintFlag{
+						Name:     "limit",
+						Required: false,
+						Aliases:  []string{"n"},
+						Value:    20,
+					},
+					&cli.BoolFlag{
+						Name:     "logs",
+						Required: false,
+						Aliases:  []string{"l"},
+						Usage:    "print the log lines saved at each crash",
+					},
+				},
+				Usage:        "Show unit starts, exits, restarts and health changes",
+				UsageText:    "pm0 history [options] <unit id>",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.History),
+				BashComplete: completes(commands.CompleteUnit),
 			},
 			{
 				Name:   "setup",
 				Action: contextProvider.Wraps(commands.Setup),
 			},
 			{
-				Name: "update",
-				Args: true,
+				Name:      "update",
+				Usage:     "Change a unit, args after the unit id replace its command",
+				UsageText: "pm0 update [options] <unit id> [bin] [args]",
+				Args:      true,
+				Flags: append(updateFlags, &cli.BoolFlag{
+					Name:  "restart",
+					Usage: "restart the unit if it's running, so the changes apply immediately",
+				}),
+				Action:       contextProvider.Wraps(commands.Update),
+				BashComplete: completes(commands.CompleteUnit),
+			},
+			{
+				Name:      "send",
+				Usage:     "Write a line to the stdin of a unit started with --stdin, stdin is sent if the text is omitted",
+				UsageText: "pm0 send [options] <unit id> [text]",
+				Args:      true,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "no-newline",
+						Aliases: []string{"n"},
+						Usage:   "don't append a newline to the text",
+					},
+				},
+				Action:       contextProvider.Wraps(commands.Send),
+				BashComplete: completes(commands.CompleteUnit),
+			},
+			{
+				Name:      "attach",
+				Usage:     "Stream the output of a unit started with --stdin and forward the input to it, ~. detaches",
+				UsageText: "pm0 attach [options] <unit id>",
+				Args:      true,
+				Flags: []cli.Flag{
+					&cli.Uint64Flag{
+						Name:    "lines",
+						Aliases: []string{"l"},
+						Value:   20,
+						Usage:   "log lines to replay before the output",
+					},
+				},
+				Action:       contextProvider.Wraps(commands.Attach),
+				BashComplete: completes(commands.CompleteUnit),
+			},
+			{
+				Name:      "exec",
+				Usage:     "Run a one-off command with the cwd, env and user of a unit",
+				UsageText: "pm0 exec [options] <unit id> -- <command> [args...]",
+				Args:      true,
+
+				UseShortOptionHandling: true,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "interactive",
+						Aliases: []string{"i"},
+						Usage:   "forward stdin to the command",
+					},
+					&cli.BoolFlag{
+						Name:    "tty",
+						Aliases: []string{"t"},
+						Usage:   "run the command in a pseudo-terminal, with -i the local terminal is put in raw mode",
+					},
+				},
+				Action:       contextProvider.Wraps(commands.Exec),
+				BashComplete: completes(commands.CompleteUnit),
+			},
+			{
+				Name:      "wait",
+				Usage:     "Wait until units are running, healthy, stopped or exited",
+				UsageText: "pm0 wait [options] <unit ids>",
+				Args:      true,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
-						Name: "name",
+						Name:  "for",
+						Value: "running",
+						Usage: "running, healthy, stopped or exited, exited sets the unit exit code as pm0 exit code",
 					},
-					&cli.StringSliceFlag{
-						Name:    "env",
-						Aliases: []string{"e"},
+					&cli.DurationFlag{
+						Name:  "timeout",
+						Usage: "give up with exit code 6 after the timeout, waits forever by default",
 					},
 				},
-				Action: contextProvider.Wraps(commands.Update),
+				Action:       contextProvider.Wraps(commands.Wait),
+				BashComplete: completes(commands.CompleteUnits),
+			},
+			{
+				Name:         "clone",
+				Usage:        "Create and start a copy of a unit, args after the unit id replace its command",
+				UsageText:    "pm0 clone [options] <unit id> [bin] [args]",
+				Args:         true,
+				Flags:        updateFlags,
+				Action:       contextProvider.Wraps(commands.Clone),
+				BashComplete: completes(commands.CompleteUnit),
+			},
+			{
+				Name:  "template",
+				Usage: "Manage unit templates, start their instances with pm0 start <template>@<instance>",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "add",
+						Usage:     "Add or replace a template, %i is replaced by the instance name",
+						UsageText: "pm0 template add [options] <name> <bin> [args]",
+						Args:      true,
+						Flags:     startFlags,
+						Action:    contextProvider.Wraps(commands.TemplateAdd),
+					},
+					{
+						Name:    "list",
+						Aliases: []string{"ls"},
+						Usage:   "List templates",
+						Action:  contextProvider.Wraps(commands.TemplateList),
+					},
+					{
+						Name:      "delete",
+						Aliases:   []string{"rm"},
+						Usage:     "Delete templates, their instances are kept",
+						UsageText: "pm0 template rm <names>",
+						Args:      true,
+						Action:    contextProvider.Wraps(commands.TemplateDelete),
+					},
+				},
+			},
+			{
+				Name:  "daemon",
+				Usage: "Inspect the daemon",
+				Subcommands: []*cli.Command{
+					{
+						Name:   "config",
+						Usage:  "Print the effective daemon config",
+						Action: contextProvider.Wraps(commands.DaemonConfig),
+					},
+				},
+			},
+			{
+				Name:  "secret",
+				Usage: "Manage secrets encrypted by the daemon",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "set",
+						Usage:     "Set a secret, the value is read from stdin if it's omitted",
+						UsageText: "pm0 secret set <name> [value]",
+						Args:      true,
+						Action:    contextProvider.Wraps(commands.SecretSet),
+					},
+					{
+						Name:      "get",
+						Usage:     "Print a secret value",
+						UsageText: "pm0 secret get <name>",
+						Args:      true,
+						Action:    contextProvider.Wraps(commands.SecretGet),
+					},
+					{
+						Name:    "list",
+						Aliases: []string{"ls"},
+						Usage:   "List secrets without their values",
+						Action:  contextProvider.Wraps(commands.SecretList),
+					},
+					{
+						Name:      "delete",
+						Aliases:   []string{"rm"},
+						Usage:     "Delete secrets",
+						UsageText: "pm0 secret rm <names>",
+						Args:      true,
+						Action:    contextProvider.Wraps(commands.SecretDelete),
+					},
+					{
+						Name:   "rotate",
+						Usage:  "Re-encrypt the secrets with a new key",
+						Action: contextProvider.Wraps(commands.SecretRotate),
+					},
+				},
+			},
+			{
+				Name:  "context",
+				Usage: "Manage named daemon connections",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "add",
+						Usage:     "Add or replace a context",
+						UsageText: "pm0 context add [options] <name> <address>",
+						Args:      true,
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:    "address",
+								Aliases: []string{"a"},
+								Usage:   "unix socket path or tcp host:port",
+							},
+							&cli.StringFlag{
+								Name:  "transport",
+								Usage: "unix, tcp or tls, inferred from the address and TLS flags",
+							},
+							&cli.StringFlag{Name: "tls-ca"},
+							&cli.StringFlag{Name: "tls-cert"},
+							&cli.StringFlag{Name: "tls-key"},
+							&cli.StringFlag{Name: "tls-server-name"},
+							&cli.StringFlag{Name: "token"},
+							&cli.StringSliceFlag{
+								Name:    "selector",
+								Aliases: []string{"s"},
+								Usage:   "default unit selectors (ids or name globs) for pm0 ls",
+							},
+						},
+						Action: contextProvider.Wraps(commands.ContextAdd),
+					},
+					{
+						Name:      "use",
+						Usage:     "Select the current context, omit the name to use the local daemon",
+						UsageText: "pm0 context use [name]",
+						Args:      true,
+						Action:    contextProvider.Wraps(commands.ContextUse),
+					},
+					{
+						Name:    "list",
+						Aliases: []string{"ls"},
+						Usage:   "List contexts",
+						Action:  contextProvider.Wraps(commands.ContextList),
+					},
+					{
+						Name:      "remove",
+						Aliases:   []string{"rm"},
+						Usage:     "Remove contexts",
+						UsageText: "pm0 context rm <names>",
+						Args:      true,
+						Action:    contextProvider.Wraps(commands.ContextRemove),
+					},
+				},
+			},
+			{
+				Name:         "completion",
+				Usage:        "Print the shell completion script of bash, zsh or fish",
+				UsageText:    "pm0 completion <bash|zsh|fish>",
+				Args:         true,
+				Action:       contextProvider.Wraps(commands.Completion),
+				BashComplete: completes(commands.CompleteCompletion),
 			},
 		},
 	}
 
+	app.OnUsageError = onUsageError
+	setOnUsageError(app.Commands)
+
+	app.BashComplete = completes(commands.Complete)
+	setBashComplete(app.Commands, completes(commands.Complete))
+
 	if err := app.Run(os.Args); err != nil {
-		pm0.Printf(err.Error())
+		pm0.PrintError(err)
+		os.Exit(pm0.ExitCode(err))
+	}
+}
+
+// onUsageError exits with ExitInvalidArguments on unknown or invalid flags
+func onUsageError(_ *cli.Context, err error, _ bool) error {
+	return &pm0.ExitError{Code: pm0.ExitInvalidArguments, Err: err}
+}
+
+func setOnUsageError(commands []*cli.Command) {
+	for _, subcommand := range commands {
+		subcommand.OnUsageError = onUsageError
+		setOnUsageError(subcommand.Subcommands)
+	}
+}
+
+// setBashComplete sets the completion of the commands without their own
+func setBashComplete(commands []*cli.Command, complete cli.BashCompleteFunc) {
+	for _, subcommand := range commands {
+		if subcommand.BashComplete == nil {
+			subcommand.BashComplete = complete
+		}
+
+		setBashComplete(subcommand.Subcommands, complete)
 	}
 }
 
@@ -185,6 +822,12 @@ var allSubCommandFlags = []cli.Flag{
 	},
 }
 
+var signalGroupFlag = &cli.BoolFlag{
+	Name:    "group",
+	Aliases: []string{"g"},
+	Usage:   "signal the whole process group of the unit instead of its main process",
+}
+
 func createAllSubcommand(action cli.ActionFunc) *cli.Command {
 	return &cli.Command{
 		Name:   "all",
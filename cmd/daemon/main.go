@@ -1,41 +1,301 @@
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net"
+	"net/http"
 	"os"
+	"os/signal"
 	"path"
-	"sync"
+	"slices"
+	"syscall"
+	"time"
 
 	"github.com/TrixiS/pm0/internal/daemon"
 	"github.com/TrixiS/pm0/internal/daemon/pb"
 	"github.com/TrixiS/pm0/internal/utils"
 	"github.com/asdine/storm/v3"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-const DaemonDBFilename = "pm0_daemon.db"
+//go:generate sh -c "go run . openapi > ../../api/openapi.json"
+
+const ConfigFilename = "pm0_daemon.yaml"
 
 func main() {
+	app := &cli.App{
+		Name:  "pm0_daemon",
+		Usage: "PM0 daemon",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				EnvVars: []string{"PM0_CONFIG"},
+				Usage:   "config file, defaults to ~/.pm0/" + ConfigFilename,
+			},
+			&cli.StringSliceFlag{
+				Name:    "listen",
+				EnvVars: []string{"PM0_LISTEN"},
+				Usage:   "listen addresses: unix:///path or tcp://host:port",
+			},
+			&cli.StringFlag{
+				Name:    "tcp-address",
+				EnvVars: []string{"PM0_TCP_ADDRESS"},
+				Usage:   "additional tcp listen address",
+			},
+			&cli.StringFlag{
+				Name:    "data-dir",
+				EnvVars: []string{"PM0_DATA_DIR"},
+			},
+			&cli.StringFlag{
+				Name:    "metrics-listen",
+				EnvVars: []string{"PM0_METRICS_LISTEN"},
+				Usage:   "address of the prometheus metrics endpoint",
+			},
+			&cli.StringFlag{
+				Name:    "dashboard-listen",
+				EnvVars: []string{"PM0_DASHBOARD_LISTEN"},
+				Usage:   "address of the web dashboard",
+			},
+			&cli.StringFlag{
+				Name:    "gateway-listen",
+				EnvVars: []string{"PM0_GATEWAY_LISTEN"},
+				Usage:   "address of the HTTP/JSON API",
+			},
+			&cli.StringFlag{
+				Name:    "tls-cert",
+				EnvVars: []string{"PM0_SERVER_TLS_CERT"},
+			},
+			&cli.StringFlag{
+				Name:    "tls-key",
+				EnvVars: []string{"PM0_SERVER_TLS_KEY"},
+			},
+			&cli.StringFlag{
+				Name:    "tls-client-ca",
+				EnvVars: []string{"PM0_SERVER_TLS_CLIENT_CA"},
+			},
+			&cli.StringFlag{
+				Name:    "tokens-file",
+				EnvVars: []string{"PM0_SERVER_TOKENS_FILE"},
+			},
+		},
+		Action: run,
+		Commands: []*cli.Command{
+			{
+				Name:   "openapi",
+				Usage:  "print the OpenAPI document of the HTTP/JSON API",
+				Action: printOpenAPI,
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		slog.Error("daemon", "err", err)
+		os.Exit(1)
+	}
+}
+
+func printOpenAPI(ctx *cli.Context) error {
+	document, err := daemon.OpenAPIDocument()
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Println(string(document))
+	return err
+}
+
+func loadConfig(ctx *cli.Context, configFilepath string) (daemon.Config, error) {
 	pm0Dirpath, err := utils.GetPM0Dirpath()
 
 	if err != nil {
-		panic(err)
+		return daemon.Config{}, err
 	}
 
-	logsDirpath := path.Join(pm0Dirpath, "logs")
+	socketFilepath, err := utils.GetDaemonSocketFilepath()
 
-	if err := os.MkdirAll(logsDirpath, 0777); err != nil {
-		panic(err)
+	if err != nil {
+		return daemon.Config{}, err
 	}
 
-	lis, err := net.Listen("tcp", "localhost:7777")
+	config, err := daemon.LoadConfig(
+		configFilepath,
+		ctx.IsSet("config"),
+		daemon.DefaultConfig(pm0Dirpath, socketFilepath),
+	)
 
 	if err != nil {
-		panic(err)
+		return config, err
+	}
+
+	if ctx.IsSet("listen") {
+		config.Listen = ctx.StringSlice("listen")
+	}
+
+	if ctx.IsSet("tcp-address") {
+		config.Listen = append(config.Listen, "tcp://"+ctx.String("tcp-address"))
+	}
+
+	for _, flag := range []struct {
+		name  string
+		value *string
+	}{
+		{"data-dir", &config.DataDir},
+		{"metrics-listen", &config.Metrics.Listen},
+		{"dashboard-listen", &config.Dashboard.Listen},
+		{"gateway-listen", &config.Gateway.Listen},
+		{"tls-cert", &config.Auth.TLSCert},
+		{"tls-key", &config.Auth.TLSKey},
+		{"tls-client-ca", &config.Auth.TLSClientCA},
+		{"tokens-file", &config.Auth.TokensFile},
+	} {
+		if ctx.IsSet(flag.name) {
+			*flag.value = ctx.String(flag.name)
+		}
+	}
+
+	return config, config.Validate()
+}
+
+// reloadConfig applies the parts of the new config that are safe to change
+// at runtime; the others are kept from the running config
+func reloadConfig(running daemon.Config, reloaded daemon.Config) daemon.Config {
+	if !slices.Equal(running.Listen, reloaded.Listen) ||
+		running.DataDir != reloaded.DataDir ||
+		running.DBFile != reloaded.DBFile ||
+		running.Logs.Dir != reloaded.Logs.Dir ||
+		running.Metrics != reloaded.Metrics ||
+		running.Dashboard != reloaded.Dashboard ||
+		running.Gateway != reloaded.Gateway ||
+		running.Auth.TLSCert != reloaded.Auth.TLSCert ||
+		running.Auth.TLSKey != reloaded.Auth.TLSKey ||
+		running.Auth.TLSClientCA != reloaded.Auth.TLSClientCA ||
+		running.Secrets != reloaded.Secrets {
+		slog.Warn("listen, data, logs dir, metrics, dashboard, gateway, tls and secrets changes require a daemon restart")
+	}
+
+	running.Logs.FileMode = reloaded.Logs.FileMode
+	running.Auth.Tokens = reloaded.Auth.Tokens
+	running.Auth.TokensFile = reloaded.Auth.TokensFile
+	running.Auth.TLSClientRoles = reloaded.Auth.TLSClientRoles
+	running.Units = reloaded.Units
+	running.Shutdown = reloaded.Shutdown
+	running.History = reloaded.History
+	running.Hooks = reloaded.Hooks
+	return running
+}
+
+func run(ctx *cli.Context) error {
+	configFilepath := ctx.String("config")
+
+	if len(configFilepath) == 0 {
+		pm0Dirpath, err := utils.GetPM0Dirpath()
+
+		if err != nil {
+			return err
+		}
+
+		configFilepath = path.Join(pm0Dirpath, ConfigFilename)
+	}
+
+	config, err := loadConfig(ctx, configFilepath)
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.DataDir, 0777); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(config.LogsDirpath(), 0777); err != nil {
+		return err
+	}
+
+	var (
+		transportCredentials = daemon.PeerCredentials{}
+		tlsConfig            *tls.Config
+	)
+
+	if len(config.Auth.TLSCert) > 0 {
+		tlsConfig, err = daemon.LoadServerTLSConfig(
+			config.Auth.TLSCert,
+			config.Auth.TLSKey,
+			config.Auth.TLSClientCA,
+		)
+
+		if err != nil {
+			return err
+		}
+
+		transportCredentials.TLS = credentials.NewTLS(tlsConfig)
+	}
+
+	tokens, err := config.LoadTokens()
+
+	if err != nil {
+		return err
+	}
+
+	for _, address := range config.Listen {
+		network, listenAddress, err := daemon.ParseListenAddress(address)
+
+		if err != nil {
+			return err
+		}
+
+		if network == "unix" {
+			continue
+		}
+
+		if err := checkListenerAuth("tcp", listenAddress, config.Auth, tokens); err != nil {
+			return err
+		}
 	}
 
-	dbFilepath := path.Join(pm0Dirpath, DaemonDBFilename)
+	if err := checkListenerAuth("dashboard", config.Dashboard.Listen, config.Auth, tokens); err != nil {
+		return err
+	}
+
+	if err := checkListenerAuth("gateway", config.Gateway.Listen, config.Auth, tokens); err != nil {
+		return err
+	}
+
+	listeners := make([]net.Listener, 0, len(config.Listen))
+
+	for _, address := range config.Listen {
+		lis, err := daemon.Listen(address)
+
+		if err != nil {
+			return err
+		}
+
+		listeners = append(listeners, lis)
+	}
+
+	// the http listeners are opened with the grpc ones, so a busy port fails
+	// the startup instead of leaving the daemon running without the endpoint
+	httpAddresses := []string{config.Metrics.Listen, config.Dashboard.Listen, config.Gateway.Listen}
+	httpListeners := make([]net.Listener, len(httpAddresses))
+
+	for i, address := range httpAddresses {
+		if len(address) == 0 {
+			continue
+		}
+
+		if httpListeners[i], err = net.Listen("tcp", address); err != nil {
+			return err
+		}
+	}
+
+	dbFilepath := config.DBFilepath()
 	dbFactory := func() *storm.DB {
 		db, err := storm.Open(dbFilepath)
 
@@ -47,40 +307,168 @@ func main() {
 	}
 
 	daemonServer := daemon.NewDaemonServer(
-		daemon.DaemonServerOptions{LogsDirpath: logsDirpath, DBFactory: dbFactory},
+		daemon.DaemonServerOptions{
+			LogsDirpath:    config.LogsDirpath(),
+			DBFactory:      dbFactory,
+			Config:         config,
+			ConfigFilepath: configFilepath,
+		},
+	)
+
+	if err := daemonServer.RestoreUnits(); err != nil {
+		return err
+	}
+
+	authenticator := daemon.NewAuthenticator(tokens, config.Auth.TLSClientRoles)
+	grpcServer := grpc.NewServer(
+		grpc.Creds(transportCredentials),
+		grpc.ChainUnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(authenticator.StreamInterceptor()),
 	)
 
-	db := dbFactory()
-	var unitModels []daemon.UnitModel
+	pb.RegisterProcessServiceServer(grpcServer, daemonServer)
+
+	metricsServer := http.Server{
+		Addr:    config.Metrics.Listen,
+		Handler: daemonServer.MetricsHandler(),
+	}
+
+	// the dashboard and the gateway are served over https with the
+	// certificate of the grpc listeners
+	dashboardServer := http.Server{
+		Addr:      config.Dashboard.Listen,
+		Handler:   daemonServer.DashboardHandler(authenticator),
+		TLSConfig: tlsConfig,
+	}
+
+	gatewayServer := http.Server{
+		Addr:      config.Gateway.Listen,
+		Handler:   daemonServer.GatewayHandler(authenticator),
+		TLSConfig: tlsConfig,
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM)
+
+	eg := errgroup.Group{}
 
-	if err := db.All(&unitModels); err != nil {
-		panic(err)
+	for _, lis := range listeners {
+		eg.Go(func() error {
+			return grpcServer.Serve(lis)
+		})
 	}
 
-	db.Close()
+	httpServers := []*http.Server{&metricsServer, &dashboardServer, &gatewayServer}
 
-	wg := sync.WaitGroup{}
-	wg.Add(len(unitModels))
+	for i, server := range httpServers {
+		lis := httpListeners[i]
 
-	for _, unitModel := range unitModels {
-		model := unitModel
+		if lis == nil {
+			continue
+		}
 
-		go func() {
-			defer wg.Done()
-			_, err := daemonServer.StartUnit(model)
+		eg.Go(func() error {
+			var err error
 
-			if err != nil {
-				slog.Error("start unit", model.ID, "err", err)
+			if server.TLSConfig != nil {
+				err = server.ServeTLS(lis, "", "")
+			} else {
+				err = server.Serve(lis)
 			}
-		}()
+
+			if !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		})
 	}
 
-	wg.Wait()
+	var stopSignal os.Signal
 
-	grpcServer := grpc.NewServer()
-	pb.RegisterProcessServiceServer(grpcServer, daemonServer)
+	for sig := range signalCh {
+		if sig != syscall.SIGHUP {
+			slog.Info("shutting down", "signal", sig, "detach", config.Shutdown.Detach)
+			stopSignal = sig
+			break
+		}
+
+		reloaded, err := loadConfig(ctx, configFilepath)
+
+		if err != nil {
+			slog.Error("reload config", "err", err)
+			continue
+		}
+
+		tokens, err := reloaded.LoadTokens()
+
+		if err != nil {
+			slog.Error("reload tokens", "err", err)
+			continue
+		}
+
+		config = reloadConfig(config, reloaded)
+		authenticator.SetTokens(tokens, config.Auth.TLSClientRoles)
+		daemonServer.SetConfig(config)
+		slog.Info("config reloaded", "filepath", configFilepath)
+	}
+
+	signal.Stop(signalCh)
+	daemonServer.BeginShutdown(stopSignal.String())
+	shutdown(grpcServer, httpServers, time.Duration(config.Shutdown.GracePeriod))
+	daemonServer.Shutdown(config.Shutdown.Detach)
+
+	if err := eg.Wait(); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+
+	slog.Info("daemon stopped")
+	return nil
+}
+
+// checkListenerAuth refuses to serve a tcp listener, the dashboard or the
+// gateway on a network address without tokens or client certificates: their
+// callers can't be identified, so they would manage every unit. Loopback
+// listeners are served with a warning
+func checkListenerAuth(name string, address string, auth daemon.AuthConfig, tokens []daemon.Token) error {
+	if len(address) == 0 || len(tokens) > 0 || len(auth.TLSClientCA) > 0 {
+		return nil
+	}
+
+	if !daemon.IsLoopbackAddress(address) {
+		return fmt.Errorf(
+			"%s listener %s accepts unauthenticated callers, configure tokens or tls_client_ca or listen on a loopback address",
+			name,
+			address,
+		)
+	}
+
+	slog.Warn("listener accepts unauthenticated callers", "listener", name, "address", address)
+	return nil
+}
+
+// shutdown stops accepting RPCs and waits for the running ones to finish
+// until the grace period is over. Endless streams like followed logs are cut
+func shutdown(grpcServer *grpc.Server, httpServers []*http.Server, gracePeriod time.Duration) {
+	stopped := make(chan struct{})
+
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), gracePeriod)
+	defer cancel()
+
+	for _, httpServer := range httpServers {
+		if err := httpServer.Shutdown(ctx); err != nil {
+			httpServer.Close()
+		}
+	}
 
-	if err := grpcServer.Serve(lis); err != nil {
-		panic(err)
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
 	}
 }
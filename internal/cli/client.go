@@ -2,7 +2,6 @@ package cli
 
 import (
 	"errors"
-	"fmt"
 	"strconv"
 )
 
@@ -12,7 +11,7 @@ func ParseStringUnitID(id string) (uint64, error) {
 	uint64ID, err := strconv.ParseUint(id, 10, 64)
 
 	if err != nil {
-		return uint64ID, fmt.Errorf("you should provide unit ids as unsigned integers: %w", err)
+		return uint64ID, ArgumentErrorf("you should provide unit ids as unsigned integers: %w", err)
 	}
 
 	return uint64ID, nil
@@ -25,7 +24,7 @@ func ParseUnitIDsFromArgs(args []string) ([]uint64, error) {
 		uint64ID, err := strconv.ParseUint(arg, 10, 64)
 
 		if err != nil {
-			return unitIDs, err
+			return unitIDs, ArgumentErrorf("you should provide unit ids as unsigned integers: %w", err)
 		}
 
 		unitIDs[i] = uint64(uint64ID)
@@ -14,8 +14,9 @@ type Context struct {
 type CommandFunc func(*Context) error
 
 type ContextProvider struct {
-	DBFactory  func() *storm.DB
-	WithClient func(f func(pb.ProcessServiceClient) error) error
+	DBFactory        func() *storm.DB
+	WithClient       func(f func(pb.ProcessServiceClient) error) error
+	DefaultSelectors func() []string
 }
 
 func (provider ContextProvider) Wraps(f CommandFunc) cli.ActionFunc {
@@ -28,3 +29,17 @@ func (provider ContextProvider) Wraps(f CommandFunc) cli.ActionFunc {
 		return f(&commandContext)
 	}
 }
+
+type CompleteFunc func(*Context)
+
+// Completes wraps the shell completion of a command
+func (provider ContextProvider) Completes(f CompleteFunc) cli.BashCompleteFunc {
+	return func(ctx *cli.Context) {
+		commandContext := Context{
+			Provider: provider,
+			CLI:      ctx,
+		}
+
+		f(&commandContext)
+	}
+}
@@ -0,0 +1,136 @@
+package commands
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+const attachInputChunkSize = 4096
+
+// detachScanner finds the ~. detach sequence at the start of an input line,
+// like ssh does. ~~ sends a single ~
+type detachScanner struct {
+	lineStart bool
+	tilde     bool
+}
+
+func newDetachScanner() *detachScanner {
+	return &detachScanner{lineStart: true}
+}
+
+// scan returns the input to forward to the unit and whether the client detached
+func (d *detachScanner) scan(input []byte) ([]byte, bool) {
+	forward := make([]byte, 0, len(input))
+
+	for _, char := range input {
+		if d.tilde {
+			d.tilde = false
+
+			if char == '.' {
+				return forward, true
+			}
+
+			if char != '~' {
+				forward = append(forward, '~')
+			}
+		} else if d.lineStart && char == '~' {
+			d.tilde = true
+			continue
+		}
+
+		forward = append(forward, char)
+		d.lineStart = char == '\n' || char == '\r'
+	}
+
+	return forward, false
+}
+
+func Attach(ctx *command.Context) error {
+	unitID, err := pm0.ParseStringUnitID(ctx.CLI.Args().First())
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		stream, err := client.Attach(ctx.CLI.Context)
+
+		if err != nil {
+			return err
+		}
+
+		sender := &streamSender[*pb.AttachRequest]{stream: stream}
+		err = sender.send(&pb.AttachRequest{UnitId: unitID, Lines: ctx.CLI.Uint64("lines")})
+
+		if err != nil {
+			return err
+		}
+
+		go forwardAttachInput(sender)
+
+		attached := false
+
+		for {
+			response, err := stream.Recv()
+
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+
+			if err != nil {
+				return err
+			}
+
+			// the first response confirms the unit accepts input
+			if !attached {
+				attached = true
+				pm0.Eprintf("attached to unit %d, type ~. at the start of a line to detach", unitID)
+
+				if response.Tty {
+					restoreTerminal, err := rawTerminal(func(rows uint32, cols uint32) {
+						sender.send(&pb.AttachRequest{Rows: rows, Cols: cols})
+					})
+
+					if err != nil {
+						return err
+					}
+
+					defer restoreTerminal()
+				}
+			}
+
+			if _, err := os.Stdout.Write(response.Data); err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// forwardAttachInput sends the terminal input to the unit until the detach
+// sequence or the end of stdin, then closes the client side of the stream
+func forwardAttachInput(sender *streamSender[*pb.AttachRequest]) {
+	defer sender.closeSend()
+
+	scanner := newDetachScanner()
+	input := make([]byte, attachInputChunkSize)
+
+	for {
+		read, err := os.Stdin.Read(input)
+		forward, detach := scanner.scan(input[:read])
+
+		if len(forward) > 0 {
+			if err := sender.send(&pb.AttachRequest{Data: forward}); err != nil {
+				return
+			}
+		}
+
+		if detach || err != nil {
+			return
+		}
+	}
+}
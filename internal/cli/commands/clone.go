@@ -0,0 +1,39 @@
+package commands
+
+import (
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+func Clone(ctx *command.Context) error {
+	unitID, err := pm0.ParseStringUnitID(ctx.CLI.Args().First())
+
+	if err != nil {
+		return err
+	}
+
+	overrides, err := buildUpdateRequest(ctx, unitID)
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.Clone(ctx.CLI.Context, &pb.CloneRequest{
+			UnitId:    unitID,
+			Overrides: overrides,
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if pm0.Output().Structured() {
+			return writeStartedUnit(response)
+		}
+
+		pm0.Printf("cloned unit %d to %s (%d) with PID %d", unitID, response.Name, response.Id, response.Pid)
+		return nil
+	})
+}
@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// completionTimeout limits the unit list request, the shell waits for it
+const completionTimeout = 2 * time.Second
+
+// completionShellEnv is set by the completion scripts, the completions of
+// zsh and fish carry descriptions
+const completionShellEnv = "PM0_COMPLETION_SHELL"
+
+var completionSignals = []string{
+	"SIGHUP",
+	"SIGINT",
+	"SIGQUIT",
+	"SIGKILL",
+	"SIGUSR1",
+	"SIGUSR2",
+	"SIGTERM",
+	"SIGCONT",
+	"SIGSTOP",
+	"SIGTSTP",
+	"SIGWINCH",
+}
+
+// completionFlagValues are the known values of the flags, keyed by the flag name
+var completionFlagValues = map[string][]string{
+	"stop-signal":    completionSignals,
+	"restart-policy": {"on-failure", "always", "never"},
+	"env-mode":       {"none", "daemon", "allow"},
+	"output":         {"table", "wide", "json", "yaml"},
+	"for":            {daemon.WaitRunning, daemon.WaitExited, daemon.WaitHealthy, daemon.WaitStopped},
+}
+
+const bashCompletionScript = `# pm0 bash completion, load it with: source <(pm0 completion bash)
+
+_pm0_complete() {
+  local cur="${COMP_WORDS[COMP_CWORD]}"
+  local -a args=("${COMP_WORDS[@]:0:COMP_CWORD}")
+  COMPREPLY=()
+
+  if [[ "$cur" == "-"* ]]; then
+    args+=("$cur")
+  fi
+
+  local opts
+  opts=$(PM0_COMPLETION_SHELL=bash "${args[@]}" --generate-bash-completion 2>/dev/null)
+  COMPREPLY=($(compgen -W "$opts" -- "$cur"))
+}
+
+complete -o bashdefault -o default -F _pm0_complete pm0
+`
+
+const zshCompletionScript = `#compdef pm0
+# pm0 zsh completion, load it with: source <(pm0 completion zsh)
+
+_pm0() {
+  local -a opts
+  local cur=${words[CURRENT]}
+  local -a args=("${(@)words[1,CURRENT-1]}")
+
+  if [[ "$cur" == "-"* ]]; then
+    args+=("$cur")
+  fi
+
+  opts=("${(@f)$(PM0_COMPLETION_SHELL=zsh "${args[@]}" --generate-bash-completion 2>/dev/null)}")
+
+  if [[ "${opts[1]}" != "" ]]; then
+    _describe 'values' opts
+  else
+    _files
+  fi
+}
+
+compdef _pm0 pm0
+`
+
+const fishCompletionScript = `# pm0 fish completion, load it with: pm0 completion fish | source
+
+function __pm0_complete
+    set -l words (commandline -opc)
+    set -l current (commandline -ct)
+
+    if string match -q -- '-*' $current
+        env PM0_COMPLETION_SHELL=fish $words $current --generate-bash-completion 2>/dev/null
+    else
+        env PM0_COMPLETION_SHELL=fish $words --generate-bash-completion 2>/dev/null
+    end
+end
+
+complete -c pm0 -f -a '(__pm0_complete)'
+`
+
+var completionScripts = map[string]string{
+	"bash": bashCompletionScript,
+	"zsh":  zshCompletionScript,
+	"fish": fishCompletionScript,
+}
+
+func Completion(ctx *command.Context) error {
+	shell := ctx.CLI.Args().First()
+	script, ok := completionScripts[shell]
+
+	if !ok {
+		return pm0.ArgumentErrorf("specify the shell: bash, zsh or fish")
+	}
+
+	_, err := fmt.Print(script)
+	return err
+}
+
+func CompleteCompletion(ctx *command.Context) {
+	if ctx.CLI.NArg() == 0 {
+		printCompletions("bash", "zsh", "fish")
+	}
+}
+
+// Complete prints the values of the flag before the cursor, or the flags and
+// the subcommands of the command
+func Complete(ctx *command.Context) {
+	completeFlags(ctx)
+}
+
+// CompleteUnits completes the unit ids of the commands taking any number of units
+func CompleteUnits(ctx *command.Context) {
+	if completeFlags(ctx) {
+		return
+	}
+
+	completeUnitIDs(ctx)
+}
+
+// CompleteUnit completes the unit id of the commands taking a single unit
+func CompleteUnit(ctx *command.Context) {
+	if completeFlags(ctx) || ctx.CLI.NArg() > 0 {
+		return
+	}
+
+	completeUnitIDs(ctx)
+}
+
+// CompleteSignal completes the units of pm0 signal and the signal after them
+func CompleteSignal(ctx *command.Context) {
+	if completeFlags(ctx) {
+		return
+	}
+
+	if ctx.CLI.NArg() > 0 {
+		printCompletions(completionSignals...)
+	}
+
+	completeUnitIDs(ctx)
+}
+
+// CompleteSignalAll completes the signal of pm0 signal all
+func CompleteSignalAll(ctx *command.Context) {
+	if completeFlags(ctx) || ctx.CLI.NArg() > 0 {
+		return
+	}
+
+	printCompletions(completionSignals...)
+}
+
+// completeFlags prints the known values of the flag before the cursor, or
+// the flags when a flag is being typed. Otherwise it prints the subcommands
+// and reports that the positional arguments are left to complete
+func completeFlags(ctx *command.Context) bool {
+	var lastArg string
+
+	// the last argument is --generate-bash-completion
+	if len(os.Args) > 2 {
+		lastArg = os.Args[len(os.Args)-2]
+	}
+
+	if !strings.HasPrefix(lastArg, "-") {
+		if subcommands := ctx.CLI.Command.Subcommands; len(subcommands) > 0 && ctx.CLI.NArg() == 0 {
+			cli.DefaultCompleteWithFlags(ctx.CLI.Command)(ctx.CLI)
+		}
+
+		return false
+	}
+
+	name := strings.TrimLeft(lastArg, "-")
+
+	for _, flag := range ctx.CLI.Command.Flags {
+		if !slices.Contains(flag.Names(), name) {
+			continue
+		}
+
+		// the arguments are completed after a complete bool flag like logs -f
+		if valueFlag, ok := flag.(interface{ TakesValue() bool }); !ok || !valueFlag.TakesValue() {
+			return false
+		}
+
+		// the values of flags without known values are left to the shell, like paths
+		printCompletions(completionFlagValues[flag.Names()[0]]...)
+		return true
+	}
+
+	cli.DefaultCompleteWithFlags(ctx.CLI.Command)(ctx.CLI)
+	return true
+}
+
+// completeUnitIDs prints the ids of the units not typed yet, with their name
+// and status as the description in zsh and fish
+func completeUnitIDs(ctx *command.Context) {
+	listCtx, cancel := context.WithTimeout(ctx.CLI.Context, completionTimeout)
+	defer cancel()
+
+	ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.List(listCtx, &emptypb.Empty{})
+
+		if err != nil {
+			return err
+		}
+
+		typed := ctx.CLI.Args().Slice()
+
+		slices.SortFunc(response.Units, func(a *pb.Unit, b *pb.Unit) int {
+			return cmp.Compare(a.Id, b.Id)
+		})
+
+		for _, unit := range response.Units {
+			id := strconv.FormatUint(unit.Id, 10)
+
+			if slices.Contains(typed, id) {
+				continue
+			}
+
+			description := fmt.Sprintf("%s (%s)", unit.Name, daemon.UnitStatus(unit.Status))
+			printCompletion(id, description)
+		}
+
+		return nil
+	})
+}
+
+func printCompletions(values ...string) {
+	for _, value := range values {
+		fmt.Println(value)
+	}
+}
+
+func printCompletion(value string, description string) {
+	switch os.Getenv(completionShellEnv) {
+	case "zsh":
+		fmt.Printf("%s:%s\n", value, strings.ReplaceAll(description, ":", `\:`))
+	case "fish":
+		fmt.Printf("%s\t%s\n", value, description)
+	default:
+		fmt.Println(value)
+	}
+}
@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/asdine/storm/v3"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func ContextAdd(ctx *command.Context) error {
+	name := ctx.CLI.Args().First()
+
+	if len(name) == 0 {
+		return pm0.ArgumentErrorf("specify a context name")
+	}
+
+	address := ctx.CLI.Args().Get(1)
+
+	if ctx.CLI.IsSet("address") {
+		address = ctx.CLI.String("address")
+	}
+
+	if len(address) == 0 {
+		return pm0.ArgumentErrorf("specify a daemon address")
+	}
+
+	transport := ctx.CLI.String("transport")
+
+	switch {
+	case len(transport) > 0:
+	case strings.HasPrefix(address, "/"):
+		transport = pm0.TransportUnix
+	case ctx.CLI.IsSet("tls-ca") || ctx.CLI.IsSet("tls-cert"):
+		transport = pm0.TransportTLS
+	default:
+		transport = pm0.TransportTCP
+	}
+
+	transport, err := pm0.ParseTransport(transport)
+
+	if err != nil {
+		return err
+	}
+
+	if ctx.CLI.IsSet("token") && transport != pm0.TransportTLS {
+		return pm0.ArgumentErrorf(
+			"tokens are only sent over tls, the %s transport can't use one: set --tls-ca or --transport %s",
+			transport,
+			pm0.TransportTLS,
+		)
+	}
+
+	model := pm0.ContextModel{
+		Name:      name,
+		Address:   address,
+		Transport: transport,
+		TLSServer: ctx.CLI.String("tls-server-name"),
+		Token:     ctx.CLI.String("token"),
+		Selectors: ctx.CLI.StringSlice("selector"),
+	}
+
+	for _, file := range []struct {
+		flag  string
+		value *string
+	}{
+		{"tls-ca", &model.TLSCA},
+		{"tls-cert", &model.TLSCert},
+		{"tls-key", &model.TLSKey},
+	} {
+		if !ctx.CLI.IsSet(file.flag) {
+			continue
+		}
+
+		if *file.value, err = filepath.Abs(ctx.CLI.String(file.flag)); err != nil {
+			return err
+		}
+	}
+
+	if transport == pm0.TransportUnix {
+		if model.Address, err = filepath.Abs(address); err != nil {
+			return err
+		}
+	}
+
+	db := ctx.Provider.DBFactory()
+	defer db.Close()
+
+	err = db.One("Name", name, &pm0.ContextModel{})
+	exists := err == nil
+
+	if err != nil && !errors.Is(err, storm.ErrNotFound) {
+		return err
+	}
+
+	// Save replaces the whole context, db.Update would keep the fields the
+	// new one clears, like its token
+	if err := db.Save(&model); err != nil {
+		return err
+	}
+
+	if exists {
+		pm0.Printf("updated context %s", name)
+		return nil
+	}
+
+	pm0.Printf("added context %s", name)
+	return nil
+}
+
+func ContextUse(ctx *command.Context) error {
+	name := ctx.CLI.Args().First()
+	db := ctx.Provider.DBFactory()
+	defer db.Close()
+
+	if len(name) > 0 {
+		if _, err := pm0.LoadContext(db, name); err != nil {
+			return err
+		}
+	}
+
+	if err := pm0.SetCurrentContextName(db, name); err != nil {
+		return err
+	}
+
+	if len(name) == 0 {
+		pm0.Printf("switched to the local daemon")
+		return nil
+	}
+
+	pm0.Printf("switched to context %s", name)
+	return nil
+}
+
+func ContextList(ctx *command.Context) error {
+	db := ctx.Provider.DBFactory()
+	defer db.Close()
+
+	var models []pm0.ContextModel
+
+	if err := db.All(&models); err != nil {
+		return err
+	}
+
+	if len(models) == 0 {
+		return fmt.Errorf("no contexts, add one with pm0 context add")
+	}
+
+	currentName, err := pm0.GetCurrentContextName(db)
+
+	if err != nil {
+		return err
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"", "Name", "Transport", "Address", "Token", "Selectors"})
+	t.SetStyle(table.StyleLight)
+	t.SetColumnConfigs([]table.ColumnConfig{
+		{
+			Name:   "Name",
+			Colors: text.Colors{text.Bold, text.FgHiCyan},
+		},
+	})
+
+	for _, model := range models {
+		current := ""
+
+		if model.Name == currentName {
+			current = "*"
+		}
+
+		token := pm0.TableNoneString
+
+		if len(model.Token) > 0 {
+			token = "set"
+		}
+
+		t.AppendRow(table.Row{
+			current,
+			model.Name,
+			model.Transport,
+			model.Address,
+			token,
+			strings.Join(model.Selectors, " "),
+		})
+	}
+
+	t.Render()
+	return nil
+}
+
+func ContextRemove(ctx *command.Context) error {
+	if ctx.CLI.NArg() == 0 {
+		return pm0.ArgumentErrorf("specify context names")
+	}
+
+	db := ctx.Provider.DBFactory()
+	defer db.Close()
+
+	currentName, err := pm0.GetCurrentContextName(db)
+
+	if err != nil {
+		return err
+	}
+
+	for _, name := range ctx.CLI.Args().Slice() {
+		if err := db.DeleteStruct(&pm0.ContextModel{Name: name}); err != nil {
+			pm0.Printf("failed to remove context %s: %v", name, err)
+			continue
+		}
+
+		if name == currentName {
+			if err := pm0.SetCurrentContextName(db, ""); err != nil {
+				return err
+			}
+		}
+
+		pm0.Printf("removed context %s", name)
+	}
+
+	return nil
+}
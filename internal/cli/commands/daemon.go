@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+func DaemonConfig(ctx *command.Context) error {
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.Config(ctx.CLI.Context, nil)
+
+		if err != nil {
+			return err
+		}
+
+		if len(response.Filepath) > 0 {
+			fmt.Printf("# %s\n", response.Filepath)
+		}
+
+		fmt.Print(response.Config)
+		return nil
+	})
+}
@@ -1,9 +1,6 @@
 package commands
 
 import (
-	"errors"
-	"io"
-
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 	"github.com/TrixiS/pm0/internal/cli/command"
 	"github.com/TrixiS/pm0/internal/daemon/pb"
@@ -43,22 +40,12 @@ func DeleteAll(ctx *command.Context) error {
 }
 
 func readDeleteStream(stream pb.ProcessService_DeleteClient) error {
-	for {
-		var response pb.StopResponse
-
-		if err := stream.RecvMsg(&response); err != nil {
-			if errors.Is(err, io.EOF) {
-				return nil
-			}
-
-			return err
-		}
-
+	return readUnitResultStream(stream, "deleted", func(response *pb.StopResponse) {
 		if response.Error == "" {
 			pm0.Printf("deleted unit %s (%d)", response.Unit.Name, response.Unit.Id)
-			continue
+			return
 		}
 
-		pm0.Printf("failed to delete unit %d: %s", response.UnitId, response.Error)
-	}
+		pm0.Eprintf("failed to delete unit %d: %s", response.UnitId, response.Error)
+	})
 }
@@ -0,0 +1,296 @@
+package commands
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+)
+
+// The documents below are the json and yaml output of the commands. Their
+// fields are part of the CLI interface, rename or remove them only with a
+// major version bump
+
+type unitDocument struct {
+	ID        uint64            `json:"id" yaml:"id"`
+	Name      string            `json:"name" yaml:"name"`
+	PID       int32             `json:"pid" yaml:"pid"`
+	Status    string            `json:"status" yaml:"status"`
+	Health    string            `json:"health" yaml:"health"`
+	Restarts  uint32            `json:"restarts" yaml:"restarts"`
+	StartedAt string            `json:"started_at,omitempty" yaml:"started_at,omitempty"`
+	OwnerUID  uint32            `json:"owner_uid" yaml:"owner_uid"`
+	Labels    map[string]string `json:"labels" yaml:"labels"`
+}
+
+type unitListDocument struct {
+	Units []unitDocument `json:"units" yaml:"units"`
+}
+
+func newUnitDocument(unit *pb.Unit) unitDocument {
+	status := daemon.UnitStatus(unit.Status)
+	document := unitDocument{
+		ID:       unit.Id,
+		Name:     unit.Name,
+		PID:      unit.Pid,
+		Status:   status.String(),
+		Health:   unit.Health,
+		Restarts: unit.RestartsCount,
+		OwnerUID: unit.OwnerUid,
+		Labels:   nonNilMap(unit.Labels),
+	}
+
+	if status == daemon.UnitStatusRunning {
+		document.StartedAt = time.Unix(unit.StartedAt, 0).Format(time.RFC3339)
+	}
+
+	return document
+}
+
+type unitEventDocument struct {
+	ID         uint64   `json:"id" yaml:"id"`
+	Type       string   `json:"type" yaml:"type"`
+	Time       string   `json:"time" yaml:"time"`
+	PID        int32    `json:"pid,omitempty" yaml:"pid,omitempty"`
+	ExitCode   int32    `json:"exit_code" yaml:"exit_code"`
+	Signal     string   `json:"signal,omitempty" yaml:"signal,omitempty"`
+	DurationMs int64    `json:"duration_ms,omitempty" yaml:"duration_ms,omitempty"`
+	Reason     string   `json:"reason,omitempty" yaml:"reason,omitempty"`
+	By         string   `json:"by,omitempty" yaml:"by,omitempty"`
+	Health     string   `json:"health,omitempty" yaml:"health,omitempty"`
+	LogTail    []string `json:"log_tail,omitempty" yaml:"log_tail,omitempty"`
+}
+
+func newUnitEventDocument(event *pb.UnitEvent) unitEventDocument {
+	return unitEventDocument{
+		ID:         event.Id,
+		Type:       event.Type,
+		Time:       time.UnixMilli(event.Time).Format(time.RFC3339),
+		PID:        event.Pid,
+		ExitCode:   event.ExitCode,
+		Signal:     event.Signal,
+		DurationMs: event.DurationMs,
+		Reason:     event.Reason,
+		By:         event.By,
+		Health:     event.Health,
+		LogTail:    event.LogTail,
+	}
+}
+
+type showDocument struct {
+	ID               uint64              `json:"id" yaml:"id"`
+	Name             string              `json:"name" yaml:"name"`
+	CWD              string              `json:"cwd" yaml:"cwd"`
+	Command          string              `json:"command" yaml:"command"`
+	Labels           map[string]string   `json:"labels" yaml:"labels"`
+	Env              []string            `json:"env" yaml:"env"`
+	EnvMode          string              `json:"env_mode" yaml:"env_mode"`
+	EnvAllow         []string            `json:"env_allow" yaml:"env_allow"`
+	EnvFiles         []string            `json:"env_files" yaml:"env_files"`
+	User             string              `json:"user" yaml:"user"`
+	Group            string              `json:"group" yaml:"group"`
+	Groups           []string            `json:"groups" yaml:"groups"`
+	OwnerUID         uint32              `json:"owner_uid" yaml:"owner_uid"`
+	RestartPolicy    string              `json:"restart_policy" yaml:"restart_policy"`
+	RestartDelayMs   int64               `json:"restart_delay_ms" yaml:"restart_delay_ms"`
+	StopSignal       string              `json:"stop_signal" yaml:"stop_signal"`
+	StopTimeoutMs    int64               `json:"stop_timeout_ms" yaml:"stop_timeout_ms"`
+	Health           string              `json:"health" yaml:"health"`
+	HealthCmd        string              `json:"health_cmd" yaml:"health_cmd"`
+	HealthIntervalMs int64               `json:"health_interval_ms" yaml:"health_interval_ms"`
+	Hooks            []string            `json:"hooks" yaml:"hooks"`
+	Stdin            bool                `json:"stdin" yaml:"stdin"`
+	TTY              bool                `json:"tty" yaml:"tty"`
+	StripANSI        bool                `json:"strip_ansi" yaml:"strip_ansi"`
+	History          []unitEventDocument `json:"history" yaml:"history"`
+}
+
+func newShowDocument(response *pb.ShowResponse) showDocument {
+	document := showDocument{
+		ID:               response.Id,
+		Name:             response.Name,
+		CWD:              response.Cwd,
+		Command:          response.Command,
+		Labels:           nonNilMap(response.Labels),
+		Env:              nonNilSlice(response.Env),
+		EnvMode:          response.EnvMode,
+		EnvAllow:         nonNilSlice(response.EnvAllow),
+		EnvFiles:         nonNilSlice(response.EnvFiles),
+		User:             response.User,
+		Group:            response.Group,
+		Groups:           nonNilSlice(response.Groups),
+		OwnerUID:         response.OwnerUid,
+		RestartPolicy:    response.RestartPolicy,
+		RestartDelayMs:   response.RestartDelayMs,
+		StopSignal:       response.StopSignal,
+		StopTimeoutMs:    response.StopTimeoutMs,
+		Health:           response.Health,
+		HealthCmd:        response.HealthCmd,
+		HealthIntervalMs: response.HealthIntervalMs,
+		Hooks:            nonNilSlice(response.Hooks),
+		Stdin:            response.Stdin,
+		TTY:              response.Tty,
+		StripANSI:        response.StripAnsi,
+		History:          make([]unitEventDocument, len(response.History)),
+	}
+
+	for i, event := range response.History {
+		document.History[i] = newUnitEventDocument(event)
+	}
+
+	return document
+}
+
+// unitResultDocument is the result of an action on a unit, the per-unit
+// errors of the stop, restart, delete and start streams are reported here
+type unitResultDocument struct {
+	ID     uint64 `json:"id" yaml:"id"`
+	Name   string `json:"name" yaml:"name"`
+	PID    int32  `json:"pid" yaml:"pid"`
+	Status string `json:"status" yaml:"status"`
+	OK     bool   `json:"ok" yaml:"ok"`
+	Error  string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+type unitResultsDocument struct {
+	Units []unitResultDocument `json:"units" yaml:"units"`
+}
+
+func newUnitResultDocument(response *pb.StopResponse) unitResultDocument {
+	document := unitResultDocument{
+		ID:    response.UnitId,
+		OK:    len(response.Error) == 0,
+		Error: response.Error,
+	}
+
+	if response.Unit != nil {
+		document.ID = response.Unit.Id
+		document.Name = response.Unit.Name
+		document.PID = response.Unit.Pid
+		document.Status = daemon.UnitStatus(response.Unit.Status).String()
+	}
+
+	return document
+}
+
+// writeStartedUnit writes the result document of a started unit
+func writeStartedUnit(response *pb.StartResponse) error {
+	return pm0.WriteDocument(unitResultsDocument{Units: []unitResultDocument{{
+		ID:     response.Id,
+		Name:   response.Name,
+		PID:    response.Pid,
+		Status: daemon.UnitStatusRunning.String(),
+		OK:     true,
+	}}})
+}
+
+// readUnitResultStream prints the responses of a unit stream with printResult,
+// or writes them as a single document when the output is structured. The
+// per-unit failures set the exit code
+func readUnitResultStream(
+	stream grpc.ServerStreamingClient[pb.StopResponse],
+	action string,
+	printResult func(*pb.StopResponse),
+) error {
+	structured := pm0.Output().Structured()
+	results := unitResultsDocument{Units: []unitResultDocument{}}
+	var failedCodes []codes.Code
+	succeeded := 0
+
+	for {
+		response, err := stream.Recv()
+
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			return err
+		}
+
+		if len(response.Error) == 0 {
+			succeeded++
+		} else {
+			failedCodes = append(failedCodes, codes.Code(response.Code))
+		}
+
+		if structured {
+			results.Units = append(results.Units, newUnitResultDocument(response))
+			continue
+		}
+
+		printResult(response)
+	}
+
+	if structured {
+		if err := pm0.WriteDocument(results); err != nil {
+			return err
+		}
+	} else if succeeded+len(failedCodes) > 1 || len(failedCodes) > 0 {
+		pm0.Printf("%s %d units, %d failed", action, succeeded, len(failedCodes))
+	}
+
+	if len(failedCodes) == 0 {
+		return nil
+	}
+
+	return &pm0.ExitError{Code: unitResultsExitCode(succeeded, failedCodes)}
+}
+
+// unitResultsExitCode is ExitPartialFailure if some units succeeded, the
+// code of the failures if they all failed the same way and ExitFailure otherwise
+func unitResultsExitCode(succeeded int, failedCodes []codes.Code) int {
+	if succeeded > 0 {
+		return pm0.ExitPartialFailure
+	}
+
+	for _, code := range failedCodes[1:] {
+		if code != failedCodes[0] {
+			return pm0.ExitFailure
+		}
+	}
+
+	return pm0.StatusExitCode(failedCodes[0])
+}
+
+type unitFieldChangeDocument struct {
+	Field string `json:"field" yaml:"field"`
+	Old   string `json:"old" yaml:"old"`
+	New   string `json:"new" yaml:"new"`
+}
+
+type updateDocument struct {
+	ID           uint64                    `json:"id" yaml:"id"`
+	Name         string                    `json:"name" yaml:"name"`
+	Changes      []unitFieldChangeDocument `json:"changes" yaml:"changes"`
+	Restarted    bool                      `json:"restarted" yaml:"restarted"`
+	PID          int32                     `json:"pid" yaml:"pid"`
+	RestartError string                    `json:"restart_error,omitempty" yaml:"restart_error,omitempty"`
+}
+
+type logLineDocument struct {
+	UnitID uint64 `json:"unit_id" yaml:"unit_id"`
+	Line   string `json:"line" yaml:"line"`
+}
+
+// nonNilSlice makes empty lists [] instead of null in the documents
+func nonNilSlice[T any](values []T) []T {
+	if values == nil {
+		return []T{}
+	}
+
+	return values
+}
+
+func nonNilMap[K comparable, V any](values map[K]V) map[K]V {
+	if values == nil {
+		return map[K]V{}
+	}
+
+	return values
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+func Events(ctx *command.Context) error {
+	selectors := ctx.CLI.Args().Slice()
+
+	if len(selectors) == 0 {
+		selectors = ctx.Provider.DefaultSelectors()
+	}
+
+	request := pb.EventsRequest{
+		Selectors: selectors,
+		Since:     ctx.CLI.Uint64("since"),
+	}
+
+	asJSON := ctx.CLI.Bool("json") || pm0.Output() == pm0.OutputJSON
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		stream, err := client.Events(ctx.CLI.Context, &request)
+
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+
+		for {
+			event, err := stream.Recv()
+
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+
+				return err
+			}
+
+			if asJSON {
+				if err := encoder.Encode(event); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			fmt.Println(formatEvent(event))
+		}
+	})
+}
+
+func formatEvent(event *pb.Event) string {
+	parts := []string{
+		formatUnitEventTime(event.Time),
+		fmt.Sprintf("#%d", event.Seq),
+		event.Type,
+	}
+
+	if event.Unit != nil {
+		parts = append(parts, fmt.Sprintf("%s (%d)", event.Unit.Name, event.Unit.Id))
+	}
+
+	if unitEvent := event.UnitEvent; unitEvent != nil {
+		parts[2] = formatUnitEventType(unitEvent)
+
+		if unitEvent.Type == daemon.UnitEventExited || unitEvent.Type == daemon.UnitEventStopped {
+			parts = append(parts, fmt.Sprintf(
+				"exit code %d after %s",
+				unitEvent.ExitCode,
+				(time.Duration(unitEvent.DurationMs)*time.Millisecond).Round(time.Second),
+			))
+
+			if len(unitEvent.Signal) > 0 {
+				parts = append(parts, unitEvent.Signal)
+			}
+		}
+
+		if details := formatUnitEventDetails(unitEvent); len(details) > 0 {
+			parts = append(parts, details)
+		}
+	}
+
+	if len(event.Message) > 0 {
+		parts = append(parts, event.Message)
+	}
+
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,126 @@
+package commands
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"golang.org/x/term"
+)
+
+func Exec(ctx *command.Context) error {
+	args := ctx.CLI.Args().Slice()
+
+	// the flags end at the unit id, the -- after it is left in the args
+	if len(args) > 1 && args[1] == "--" {
+		args = append(args[:1], args[2:]...)
+	}
+
+	if len(args) < 2 {
+		return pm0.ArgumentErrorf("specify the unit id and the command to run")
+	}
+
+	unitID, err := pm0.ParseStringUnitID(args[0])
+
+	if err != nil {
+		return err
+	}
+
+	tty := ctx.CLI.Bool("tty")
+	interactive := ctx.CLI.Bool("interactive")
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		stream, err := client.Exec(ctx.CLI.Context)
+
+		if err != nil {
+			return err
+		}
+
+		request := &pb.ExecRequest{UnitId: unitID, Bin: args[1], Args: args[2:], Tty: tty}
+
+		if cols, rows, err := term.GetSize(int(os.Stdin.Fd())); tty && err == nil {
+			request.Rows = uint32(rows)
+			request.Cols = uint32(cols)
+		}
+
+		sender := &streamSender[*pb.ExecRequest]{stream: stream}
+
+		if err := sender.send(request); err != nil {
+			return err
+		}
+
+		if !interactive {
+			sender.closeSend()
+		} else {
+			// the keys of a tty command go to it as typed, Ctrl-C included
+			if tty {
+				restoreTerminal, err := rawTerminal(func(rows uint32, cols uint32) {
+					sender.send(&pb.ExecRequest{Rows: rows, Cols: cols})
+				})
+
+				if err != nil {
+					return err
+				}
+
+				defer restoreTerminal()
+			}
+
+			go forwardExecInput(sender)
+		}
+
+		for {
+			response, err := stream.Recv()
+
+			if errors.Is(err, io.EOF) {
+				return errors.New("exec stream closed before the command exited")
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if _, err := os.Stdout.Write(response.Stdout); err != nil {
+				return err
+			}
+
+			if _, err := os.Stderr.Write(response.Stderr); err != nil {
+				return err
+			}
+
+			if !response.Exited {
+				continue
+			}
+
+			if exitCode := pm0.ProcessExitCode(response.ExitCode, response.Signal); exitCode != pm0.ExitOK {
+				return &pm0.ExitError{Code: exitCode}
+			}
+
+			return nil
+		}
+	})
+}
+
+// forwardExecInput sends stdin to the command until its end, then closes the
+// client side of the stream, which closes the command stdin
+func forwardExecInput(sender *streamSender[*pb.ExecRequest]) {
+	defer sender.closeSend()
+
+	input := make([]byte, attachInputChunkSize)
+
+	for {
+		read, err := os.Stdin.Read(input)
+
+		if read > 0 {
+			if err := sender.send(&pb.ExecRequest{Data: input[:read]}); err != nil {
+				return
+			}
+		}
+
+		if err != nil {
+			return
+		}
+	}
+}
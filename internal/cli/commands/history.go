@@ -0,0 +1,135 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func History(ctx *command.Context) error {
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		unitID, err := pm0.ParseStringUnitID(ctx.CLI.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		response, err := client.History(ctx.CLI.Context, &pb.HistoryRequest{
+			UnitId: unitID,
+			Limit:  uint32(ctx.CLI.Uint("limit")),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if len(response.Events) == 0 {
+			pm0.Printf("unit %d has no history", unitID)
+			return nil
+		}
+
+		renderUnitEvents(response.Events)
+
+		if !ctx.CLI.Bool("logs") {
+			return nil
+		}
+
+		for _, event := range response.Events {
+			if len(event.LogTail) == 0 {
+				continue
+			}
+
+			fmt.Println()
+			pm0.Printf("last log lines before %s", formatUnitEventTime(event.Time))
+
+			for _, line := range event.LogTail {
+				fmt.Println(line)
+			}
+		}
+
+		return nil
+	})
+}
+
+// renderUnitEvents prints the events in chronological order
+func renderUnitEvents(events []*pb.UnitEvent) {
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.AppendHeader(table.Row{"Time", "Event", "PID", "Exit", "Signal", "Duration", "Details"})
+	t.SetStyle(table.StyleLight)
+	t.Style().Options.SeparateRows = false
+
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		exitCode := ""
+		duration := ""
+
+		if event.Type == daemon.UnitEventExited || event.Type == daemon.UnitEventStopped {
+			exitCode = fmt.Sprint(event.ExitCode)
+			duration = (time.Duration(event.DurationMs) * time.Millisecond).Round(time.Second).String()
+		}
+
+		pid := ""
+
+		if event.Pid != 0 {
+			pid = fmt.Sprint(event.Pid)
+		}
+
+		t.AppendRow(table.Row{
+			formatUnitEventTime(event.Time),
+			formatUnitEventType(event),
+			pid,
+			exitCode,
+			event.Signal,
+			duration,
+			formatUnitEventDetails(event),
+		})
+	}
+
+	t.Render()
+}
+
+func formatUnitEventTime(unixMilli int64) string {
+	return time.UnixMilli(unixMilli).Format(time.DateTime)
+}
+
+func formatUnitEventType(event *pb.UnitEvent) string {
+	switch {
+	case event.Type == daemon.UnitEventHealth && event.Health == daemon.UnitHealthHealthy.String():
+		return text.FgGreen.Sprint(event.Health)
+	case event.Type == daemon.UnitEventHealth:
+		return text.FgRed.Sprint(event.Health)
+	case event.Type == daemon.UnitEventExited && (event.ExitCode != 0 || len(event.Signal) > 0):
+		return text.FgRed.Sprint("failed")
+	case event.Type == daemon.UnitEventStopped:
+		return text.FgYellow.Sprint(event.Type)
+	default:
+		return event.Type
+	}
+}
+
+func formatUnitEventDetails(event *pb.UnitEvent) string {
+	details := make([]string, 0, 3)
+
+	if len(event.Reason) > 0 {
+		details = append(details, event.Reason)
+	}
+
+	if len(event.By) > 0 {
+		details = append(details, "by "+event.By)
+	}
+
+	if len(event.LogTail) > 0 {
+		details = append(details, fmt.Sprintf("%d log lines saved", len(event.LogTail)))
+	}
+
+	return strings.Join(details, ", ")
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"os"
 	"slices"
+	"strings"
 
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 	"github.com/TrixiS/pm0/internal/cli/command"
@@ -20,7 +21,19 @@ func List(ctx *command.Context) error {
 			return err
 		}
 
-		if len(response.Units) == 0 {
+		selectors := ctx.CLI.Args().Slice()
+
+		if len(selectors) == 0 {
+			selectors = ctx.Provider.DefaultSelectors()
+		}
+
+		response.Units = slices.DeleteFunc(response.Units, func(unit *pb.Unit) bool {
+			return !daemon.UnitMatchesSelectors(unit, selectors)
+		})
+
+		output := pm0.Output()
+
+		if len(response.Units) == 0 && !output.Structured() {
 			return pm0.ErrEmptyUnits
 		}
 
@@ -36,9 +49,26 @@ func List(ctx *command.Context) error {
 			return 0
 		})
 
+		if output.Structured() {
+			document := unitListDocument{Units: make([]unitDocument, len(response.Units))}
+
+			for i, unit := range response.Units {
+				document.Units[i] = newUnitDocument(unit)
+			}
+
+			return pm0.WriteDocument(document)
+		}
+
+		wide := output == pm0.OutputWide
+		header := table.Row{"ID", "Name", "PID", "Status", "Restarts", "Uptime"}
+
+		if wide {
+			header = append(header, "Health", "Owner UID", "Labels")
+		}
+
 		t := table.NewWriter()
 		t.SetOutputMirror(os.Stdout)
-		t.AppendHeader(table.Row{"ID", "Name", "PID", "Status", "Restarts", "Uptime"})
+		t.AppendHeader(header)
 		t.SetStyle(table.StyleLight)
 		t.Style().Options.SeparateRows = false
 		t.SetColumnConfigs([]table.ColumnConfig{
@@ -51,14 +81,25 @@ func List(ctx *command.Context) error {
 		for _, unit := range response.Units {
 			unitStatus := daemon.UnitStatus(unit.Status)
 
-			t.AppendRow(table.Row{
+			row := table.Row{
 				unit.Id,
 				unit.Name,
 				unit.Pid,
 				pm0.FormatUnitStatus(unitStatus),
 				unit.RestartsCount,
 				pm0.FormatUnitUptime(unit.StartedAt, unitStatus),
-			})
+			}
+
+			if wide {
+				row = append(
+					row,
+					formatShowValue(unit.Health),
+					unit.OwnerUid,
+					formatShowValue(strings.Join(daemon.FormatLabels(unit.Labels), " ")),
+				)
+			}
+
+			t.AppendRow(row)
 		}
 
 		t.Render()
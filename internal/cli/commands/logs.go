@@ -3,6 +3,7 @@ package commands
 import (
 	"fmt"
 	"io"
+	"os"
 
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 	"github.com/TrixiS/pm0/internal/cli/command"
@@ -34,6 +35,18 @@ func Logs(ctx *command.Context) error {
 		}
 
 		tailLines := make([]string, 0, linesCount)
+		printLine := func(line string) error {
+			fmt.Println(line)
+			return nil
+		}
+
+		// structured logs are a document per line, so they can be followed
+		if pm0.Output().Structured() {
+			encoder := pm0.NewDocumentEncoder(os.Stdout, false)
+			printLine = func(line string) error {
+				return encoder.Encode(logLineDocument{UnitID: unitID, Line: line})
+			}
+		}
 
 		for {
 			response := pb.LogsResponse{}
@@ -52,12 +65,17 @@ func Logs(ctx *command.Context) error {
 			}
 
 			if len(tailLines) == 0 {
-				fmt.Println(response.Line)
+				if err := printLine(response.Line); err != nil {
+					return err
+				}
+
 				continue
 			}
 
 			for i := len(tailLines) - 1; i >= 0; i-- {
-				fmt.Println(tailLines[i])
+				if err := printLine(tailLines[i]); err != nil {
+					return err
+				}
 			}
 
 			tailLines = nil
@@ -1,9 +1,6 @@
 package commands
 
 import (
-	"errors"
-	"io"
-
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 	"github.com/TrixiS/pm0/internal/cli/command"
 	"github.com/TrixiS/pm0/internal/daemon/pb"
@@ -42,17 +39,7 @@ func RestartAll(ctx *command.Context) error {
 }
 
 func readRestartStream(stream pb.ProcessService_RestartClient) error {
-	for {
-		var response pb.StopResponse
-
-		if err := stream.RecvMsg(&response); err != nil {
-			if errors.Is(err, io.EOF) {
-				return nil
-			}
-
-			return err
-		}
-
+	return readUnitResultStream(stream, "restarted", func(response *pb.StopResponse) {
 		if response.Error == "" {
 			pm0.Printf(
 				"restarted unit %s (%d) with PID %d",
@@ -61,9 +48,9 @@ func readRestartStream(stream pb.ProcessService_RestartClient) error {
 				response.Unit.Pid,
 			)
 
-			continue
+			return
 		}
 
-		pm0.Printf("failed to restart unit %d: %s", response.UnitId, response.Error)
-	}
+		pm0.Eprintf("failed to restart unit %d: %s", response.UnitId, response.Error)
+	})
 }
@@ -0,0 +1,165 @@
+package commands
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func SecretSet(ctx *command.Context) error {
+	name := ctx.CLI.Args().First()
+
+	if len(name) == 0 {
+		return pm0.ArgumentErrorf("specify a secret name")
+	}
+
+	value := ctx.CLI.Args().Get(1)
+
+	if ctx.CLI.NArg() < 2 {
+		var err error
+
+		if value, err = readSecretValue(); err != nil {
+			return err
+		}
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		_, err := client.SecretSet(ctx.CLI.Context, &pb.SecretSetRequest{Name: name, Value: value})
+
+		if err != nil {
+			return err
+		}
+
+		pm0.Printf("set secret %s, reference it as @secret:%s", name, name)
+		return nil
+	})
+}
+
+// readSecretValue reads a line from the terminal or the whole piped stdin,
+// so the value doesn't end up in the shell history
+func readSecretValue() (string, error) {
+	stdinInfo, err := os.Stdin.Stat()
+
+	if err != nil {
+		return "", err
+	}
+
+	if stdinInfo.Mode()&os.ModeCharDevice != 0 {
+		fmt.Fprint(os.Stderr, "value: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return "", err
+		}
+
+		return strings.TrimRight(line, "\r\n"), nil
+	}
+
+	value, err := io.ReadAll(os.Stdin)
+
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(string(value), "\n"), nil
+}
+
+func SecretGet(ctx *command.Context) error {
+	name := ctx.CLI.Args().First()
+
+	if len(name) == 0 {
+		return pm0.ArgumentErrorf("specify a secret name")
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.SecretGet(ctx.CLI.Context, &pb.SecretRequest{Name: name})
+
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(response.Value)
+		return nil
+	})
+}
+
+func SecretList(ctx *command.Context) error {
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.SecretList(ctx.CLI.Context, nil)
+
+		if err != nil {
+			return err
+		}
+
+		if len(response.Secrets) == 0 {
+			pm0.Printf("no secrets")
+			return nil
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"Name", "Owner UID", "Updated"})
+		t.SetStyle(table.StyleLight)
+		t.Style().Options.SeparateRows = false
+		t.SetColumnConfigs([]table.ColumnConfig{
+			{
+				Name:   "Name",
+				Colors: text.Colors{text.Bold, text.FgHiCyan},
+			},
+		})
+
+		for _, secret := range response.Secrets {
+			t.AppendRow(table.Row{
+				secret.Name,
+				secret.OwnerUid,
+				time.UnixMilli(secret.UpdatedAt).Format(time.DateTime),
+			})
+		}
+
+		t.Render()
+		return nil
+	})
+}
+
+func SecretDelete(ctx *command.Context) error {
+	if ctx.CLI.NArg() == 0 {
+		return pm0.ArgumentErrorf("specify secret names")
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		for _, name := range ctx.CLI.Args().Slice() {
+			_, err := client.SecretDelete(ctx.CLI.Context, &pb.SecretRequest{Name: name})
+
+			if err != nil {
+				return err
+			}
+
+			pm0.Printf("deleted secret %s", name)
+		}
+
+		return nil
+	})
+}
+
+func SecretRotate(ctx *command.Context) error {
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.SecretRotate(ctx.CLI.Context, nil)
+
+		if err != nil {
+			return err
+		}
+
+		pm0.Printf("rotated the secret key, re-encrypted %d secrets", response.Secrets)
+		return nil
+	})
+}
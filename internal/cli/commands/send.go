@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+func Send(ctx *command.Context) error {
+	unitID, err := pm0.ParseStringUnitID(ctx.CLI.Args().First())
+
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+
+	if ctx.CLI.NArg() > 1 {
+		text := strings.Join(ctx.CLI.Args().Tail(), " ")
+
+		if !ctx.CLI.Bool("no-newline") {
+			text += "\n"
+		}
+
+		data = []byte(text)
+	} else if data, err = io.ReadAll(os.Stdin); err != nil {
+		return err
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		_, err := client.Send(ctx.CLI.Context, &pb.SendRequest{UnitId: unitID, Data: data})
+		return err
+	})
+}
@@ -18,6 +18,8 @@ Description="PM0 Daemon"
 User=root
 Type=exec
 KillSignal=SIGINT
+KillMode=process
+TimeoutStopSec=120
 WorkingDirectory=%s
 ExecStart=%s
 Restart=on-failure
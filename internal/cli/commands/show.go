@@ -3,10 +3,12 @@ package commands
 import (
 	"os"
 	"strings"
+	"time"
 
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 
 	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
 	"github.com/TrixiS/pm0/internal/daemon/pb"
 	"github.com/jedib0t/go-pretty/v6/table"
 	"github.com/jedib0t/go-pretty/v6/text"
@@ -26,6 +28,10 @@ func Show(ctx *command.Context) error {
 			return err
 		}
 
+		if pm0.Output().Structured() {
+			return pm0.WriteDocument(newShowDocument(response))
+		}
+
 		t := table.NewWriter()
 		t.SetOutputMirror(os.Stdout)
 		t.SetStyle(table.StyleLight)
@@ -46,10 +52,48 @@ func Show(ctx *command.Context) error {
 			{"Name", response.Name},
 			{"CWD", response.Cwd},
 			{"Command", response.Command},
+			{"Labels", formatShowValue(strings.Join(daemon.FormatLabels(response.Labels), " "))},
 			{"Env", strings.Join(response.Env, " ")},
+			{"Env mode", formatEnvMode(response.EnvMode, response.EnvAllow)},
+			{"Env files", formatShowValue(strings.Join(response.EnvFiles, "\n"))},
+			{"User", formatShowValue(response.User)},
+			{"Group", formatShowValue(response.Group)},
+			{"Groups", formatShowValue(strings.Join(response.Groups, " "))},
+			{"Restart", response.RestartPolicy},
+			{"Restart delay", time.Duration(response.RestartDelayMs) * time.Millisecond},
+			{"Stop signal", response.StopSignal},
+			{"Stop timeout", time.Duration(response.StopTimeoutMs) * time.Millisecond},
+			{"Health", formatShowValue(response.Health)},
+			{"Health cmd", formatShowValue(response.HealthCmd)},
+			{"Health interval", time.Duration(response.HealthIntervalMs) * time.Millisecond},
+			{"Hooks", formatShowValue(strings.Join(response.Hooks, "\n"))},
+			{"Stdin", response.Stdin},
+			{"TTY", response.Tty},
+			{"Strip ANSI", response.StripAnsi},
 		})
 
 		t.Render()
+
+		if len(response.History) > 0 {
+			renderUnitEvents(response.History)
+		}
+
 		return nil
 	})
 }
+
+func formatEnvMode(mode string, allow []string) string {
+	if mode != "allow" {
+		return mode
+	}
+
+	return mode + " " + strings.Join(allow, " ")
+}
+
+func formatShowValue(value string) string {
+	if len(value) == 0 {
+		return pm0.TableNoneString
+	}
+
+	return value
+}
@@ -0,0 +1,91 @@
+package commands
+
+import (
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+// Signal sends the signal given as the last argument to the units
+func Signal(ctx *command.Context) error {
+	args := ctx.CLI.Args().Slice()
+
+	if len(args) < 2 {
+		return pm0.ArgumentErrorf("specify the unit ids and the signal")
+	}
+
+	signalName, err := parseSignalArg(args[len(args)-1])
+
+	if err != nil {
+		return err
+	}
+
+	unitIDs, err := pm0.ParseUnitIDsFromArgs(args[:len(args)-1])
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		stream, err := client.Signal(ctx.CLI.Context, &pb.SignalRequest{
+			UnitIds: unitIDs,
+			Signal:  signalName,
+			Group:   ctx.CLI.Bool("group"),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return readSignalStream(stream, signalName)
+	})
+}
+
+func SignalAll(ctx *command.Context) error {
+	if ctx.CLI.NArg() != 1 {
+		return pm0.ArgumentErrorf("specify the signal")
+	}
+
+	signalName, err := parseSignalArg(ctx.CLI.Args().First())
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		stream, err := client.SignalAll(ctx.CLI.Context, &pb.SignalAllRequest{
+			Except: ctx.CLI.Uint64Slice("except"),
+			Signal: signalName,
+			Group:  ctx.CLI.Bool("group"),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		return readSignalStream(stream, signalName)
+	})
+}
+
+// parseSignalArg checks the signal before the request and returns its full name
+func parseSignalArg(arg string) (string, error) {
+	signal, err := daemon.ParseSignal(arg)
+
+	if err != nil {
+		return "", pm0.ArgumentErrorf("%w", err)
+	}
+
+	return daemon.SignalName(signal), nil
+}
+
+func readSignalStream(stream pb.ProcessService_SignalClient, signalName string) error {
+	return readUnitResultStream(stream, "signaled", func(response *pb.StopResponse) {
+		if len(response.Error) == 0 {
+			pm0.Printf("sent %s to unit %s (%d)", signalName, response.Unit.Name, response.UnitId)
+			return
+		}
+
+		pm0.Eprintf("failed to signal unit %d: %s", response.UnitId, response.Error)
+	})
+}
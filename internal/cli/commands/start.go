@@ -1,26 +1,60 @@
 package commands
 
 import (
-	"fmt"
 	"os"
 	"path"
 
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
 	"github.com/TrixiS/pm0/internal/daemon/pb"
 )
 
 func Start(ctx *command.Context) error {
 	if ctx.CLI.NArg() == 0 {
-		return fmt.Errorf("specify a binary and optional args")
+		return pm0.ArgumentErrorf("specify a binary and optional args, unit ids or a template instance")
 	}
 
-	osCwd, err := os.Getwd()
+	if unitIDs, err := pm0.ParseUnitIDsFromArgs(ctx.CLI.Args().Slice()); err == nil {
+		return startExisting(ctx, unitIDs)
+	}
+
+	if ctx.CLI.NArg() == 1 {
+		if template, instance, ok := daemon.ParseTemplateInstance(ctx.CLI.Args().First()); ok {
+			return startTemplate(ctx, template, instance)
+		}
+	}
+
+	request, err := buildStartRequest(ctx, ctx.CLI.Args().First(), ctx.CLI.Args().Tail())
 
 	if err != nil {
 		return err
 	}
 
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.Start(ctx.CLI.Context, request)
+
+		if err != nil {
+			return err
+		}
+
+		if pm0.Output().Structured() {
+			return writeStartedUnit(response)
+		}
+
+		pm0.Printf("started unit %s (%d) with PID %d", response.Name, response.Id, response.Pid)
+		return nil
+	})
+}
+
+// buildStartRequest reads the start flags, they are shared with template set
+func buildStartRequest(ctx *command.Context, bin string, args []string) (*pb.StartRequest, error) {
+	osCwd, err := os.Getwd()
+
+	if err != nil {
+		return nil, err
+	}
+
 	cwd := osCwd
 
 	if ctx.CLI.IsSet("cwd") {
@@ -37,25 +71,100 @@ func Start(ctx *command.Context) error {
 		name = path.Base(osCwd)
 	}
 
-	bin := ctx.CLI.Args().First()
-	args := ctx.CLI.Args().Tail()
+	envFiles := ctx.CLI.StringSlice("env-file")
+
+	for i, envFile := range envFiles {
+		if !path.IsAbs(envFile) {
+			envFiles[i] = path.Join(osCwd, envFile)
+		}
+	}
+
+	labels, err := daemon.ParseLabels(ctx.CLI.StringSlice("label"))
+
+	if err != nil {
+		return nil, err
+	}
 
 	request := pb.StartRequest{
-		Name: name,
-		Bin:  bin,
-		Args: args,
-		Cwd:  cwd,
-		Env:  ctx.CLI.StringSlice("env"),
+		Name:   name,
+		Bin:    bin,
+		Args:   args,
+		Cwd:    cwd,
+		Env:    ctx.CLI.StringSlice("env"),
+		User:   ctx.CLI.String("user"),
+		Group:  ctx.CLI.String("group"),
+		Groups: ctx.CLI.StringSlice("groups"),
+
+		RestartPolicy:  ctx.CLI.String("restart-policy"),
+		RestartDelayMs: ctx.CLI.Duration("restart-delay").Milliseconds(),
+		StopSignal:     ctx.CLI.String("stop-signal"),
+		StopTimeoutMs:  ctx.CLI.Duration("stop-timeout").Milliseconds(),
+
+		HealthCmd:        ctx.CLI.String("health-cmd"),
+		HealthIntervalMs: ctx.CLI.Duration("health-interval").Milliseconds(),
+		Hooks:            ctx.CLI.StringSlice("hook"),
+
+		EnvMode:  ctx.CLI.String("env-mode"),
+		EnvAllow: ctx.CLI.StringSlice("env-allow"),
+		EnvFiles: envFiles,
+		Labels:   labels,
+		Stdin:    ctx.CLI.Bool("stdin"),
+
+		Tty:       ctx.CLI.Bool("tty"),
+		StripAnsi: ctx.CLI.Bool("strip-ansi"),
 	}
 
+	return &request, nil
+}
+
+func startTemplate(ctx *command.Context, template string, instance string) error {
 	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
-		response, err := client.Start(ctx.CLI.Context, &request)
+		response, err := client.StartTemplate(ctx.CLI.Context, &pb.StartTemplateRequest{
+			Template: template,
+			Instance: instance,
+		})
 
 		if err != nil {
 			return err
 		}
 
-		pm0.Printf("started unit %s (%d) with PID %d", name, response.Id, response.Pid)
+		if pm0.Output().Structured() {
+			return writeStartedUnit(response)
+		}
+
+		pm0.Printf(
+			"started unit %s (%d) with PID %d",
+			response.Name,
+			response.Id,
+			response.Pid,
+		)
+
 		return nil
 	})
 }
+
+// startExisting starts stopped units instead of creating a new one
+func startExisting(ctx *command.Context, unitIDs []uint64) error {
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		stream, err := client.StartExisting(ctx.CLI.Context, &pb.StopRequest{UnitIds: unitIDs})
+
+		if err != nil {
+			return err
+		}
+
+		return readUnitResultStream(stream, "started", func(response *pb.StopResponse) {
+			if len(response.Error) == 0 {
+				pm0.Printf(
+					"started unit %s (%d) with PID %d",
+					response.Unit.Name,
+					response.Unit.Id,
+					response.Unit.Pid,
+				)
+
+				return
+			}
+
+			pm0.Eprintf("failed to start unit %d: %s", response.UnitId, response.Error)
+		})
+	})
+}
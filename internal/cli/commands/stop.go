@@ -1,9 +1,6 @@
 package commands
 
 import (
-	"errors"
-	"io"
-
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 	"github.com/TrixiS/pm0/internal/cli/command"
 	"github.com/TrixiS/pm0/internal/daemon/pb"
@@ -42,22 +39,12 @@ func StopAll(ctx *command.Context) error {
 }
 
 func readStopStream(stream pb.ProcessService_StopClient) error {
-	for {
-		var response pb.StopResponse
-
-		if err := stream.RecvMsg(&response); err != nil {
-			if errors.Is(err, io.EOF) {
-				return nil
-			}
-
-			return err
-		}
-
+	return readUnitResultStream(stream, "stopped", func(response *pb.StopResponse) {
 		if len(response.Error) == 0 {
 			pm0.Printf("stopped unit %s (%d)", response.Unit.Name, response.UnitId)
-			continue
+			return
 		}
 
-		pm0.Printf("failed to stop unit %d: %s", response.UnitId, response.Error)
-	}
+		pm0.Eprintf("failed to stop unit %d: %s", response.UnitId, response.Error)
+	})
 }
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"os"
+
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+func TemplateAdd(ctx *command.Context) error {
+	if ctx.CLI.NArg() < 2 {
+		return pm0.ArgumentErrorf("specify a template name and a binary with optional args")
+	}
+
+	name := ctx.CLI.Args().First()
+	unit, err := buildStartRequest(ctx, ctx.CLI.Args().Get(1), ctx.CLI.Args().Slice()[2:])
+
+	if err != nil {
+		return err
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		_, err := client.TemplateSet(ctx.CLI.Context, &pb.TemplateSetRequest{Name: name, Unit: unit})
+
+		if err != nil {
+			return err
+		}
+
+		pm0.Printf("added template %s, start instances with pm0 start %s@<instance>", name, name)
+		return nil
+	})
+}
+
+func TemplateList(ctx *command.Context) error {
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.TemplateList(ctx.CLI.Context, nil)
+
+		if err != nil {
+			return err
+		}
+
+		if len(response.Templates) == 0 {
+			pm0.Printf("no templates")
+			return nil
+		}
+
+		t := table.NewWriter()
+		t.SetOutputMirror(os.Stdout)
+		t.AppendHeader(table.Row{"Name", "Owner UID", "Command"})
+		t.SetStyle(table.StyleLight)
+		t.Style().Options.SeparateRows = false
+		t.SetColumnConfigs([]table.ColumnConfig{
+			{
+				Name:   "Name",
+				Colors: text.Colors{text.Bold, text.FgHiCyan},
+			},
+		})
+
+		for _, template := range response.Templates {
+			t.AppendRow(table.Row{template.Name, template.OwnerUid, template.Command})
+		}
+
+		t.Render()
+		return nil
+	})
+}
+
+func TemplateDelete(ctx *command.Context) error {
+	if ctx.CLI.NArg() == 0 {
+		return pm0.ArgumentErrorf("specify template names")
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		for _, name := range ctx.CLI.Args().Slice() {
+			_, err := client.TemplateDelete(ctx.CLI.Context, &pb.TemplateRequest{Name: name})
+
+			if err != nil {
+				return err
+			}
+
+			pm0.Printf("deleted template %s", name)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,74 @@
+package commands
+
+import (
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"golang.org/x/term"
+)
+
+// streamSender serializes the sends of the input and the window size to the
+// client side of a bidi stream
+type streamSender[T any] struct {
+	mu     sync.Mutex
+	stream interface {
+		Send(T) error
+		CloseSend() error
+	}
+}
+
+func (s *streamSender[T]) send(request T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.Send(request)
+}
+
+func (s *streamSender[T]) closeSend() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.CloseSend()
+}
+
+// rawTerminal puts the terminal in raw mode for the pseudo-terminals of the
+// daemon, so the keys go to the process as typed, and calls resize with the
+// window size now and on every resize. It returns the function restoring the
+// terminal, stdin that isn't a terminal is left as is
+func rawTerminal(resize func(rows uint32, cols uint32)) (func(), error) {
+	fd := int(os.Stdin.Fd())
+
+	if !term.IsTerminal(fd) {
+		return func() {}, nil
+	}
+
+	state, err := term.MakeRaw(fd)
+
+	if err != nil {
+		return nil, err
+	}
+
+	resized := make(chan os.Signal, 1)
+	signal.Notify(resized, syscall.SIGWINCH)
+	stop := make(chan struct{})
+
+	go func() {
+		for {
+			if cols, rows, err := term.GetSize(fd); err == nil {
+				resize(uint32(rows), uint32(cols))
+			}
+
+			select {
+			case <-stop:
+				return
+			case <-resized:
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(resized)
+		close(stop)
+		term.Restore(fd, state)
+	}, nil
+}
@@ -1,11 +1,44 @@
 package commands
 
 import (
+	"fmt"
+	"os"
+	"path"
+
 	pm0 "github.com/TrixiS/pm0/internal/cli"
 	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
 	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
+// updateFlagFields maps the update flags to the update mask fields
+var updateFlagFields = []struct {
+	flag  string
+	field string
+}{
+	{"name", "name"},
+	{"cwd", "cwd"},
+	{"env", "env"},
+	{"env-file", "env_files"},
+	{"env-mode", "env_mode"},
+	{"env-allow", "env_allow"},
+	{"label", "labels"},
+	{"user", "user"},
+	{"group", "group"},
+	{"groups", "groups"},
+	{"restart-policy", "restart_policy"},
+	{"restart-delay", "restart_delay_ms"},
+	{"stop-signal", "stop_signal"},
+	{"stop-timeout", "stop_timeout_ms"},
+	{"health-cmd", "health_cmd"},
+	{"health-interval", "health_interval_ms"},
+	{"hook", "hooks"},
+	{"stdin", "stdin"},
+	{"tty", "tty"},
+	{"strip-ansi", "strip_ansi"},
+}
+
 func Update(ctx *command.Context) error {
 	unitID, err := pm0.ParseStringUnitID(ctx.CLI.Args().First())
 
@@ -13,27 +46,148 @@ func Update(ctx *command.Context) error {
 		return err
 	}
 
-	name := ctx.CLI.String("name")
+	request, err := buildUpdateRequest(ctx, unitID)
 
-	err = ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
-		response, err := client.Update(ctx.CLI.Context, &pb.UpdateRequst{
-			UnitId: unitID,
-			Name:   name,
-			Env:    ctx.CLI.StringSlice("env"),
-		})
+	if err != nil {
+		return err
+	}
+
+	if len(request.UpdateMask.Paths) == 0 {
+		return pm0.ArgumentErrorf("specify the fields to update")
+	}
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.Update(ctx.CLI.Context, request)
 
 		if err != nil {
 			return err
 		}
 
-		name = response.Name
+		if pm0.Output().Structured() {
+			return writeUpdateDocument(unitID, request, response)
+		}
+
+		if len(response.Changes) == 0 {
+			pm0.Printf("unit %s (%d) is up to date", response.Name, unitID)
+			return nil
+		}
+
+		pm0.Printf("updated %s (%d)", response.Name, unitID)
+
+		for _, change := range response.Changes {
+			fmt.Printf(
+				"  %s: %s -> %s\n",
+				change.Field,
+				formatShowValue(change.Old),
+				formatShowValue(change.New),
+			)
+		}
+
+		if len(response.RestartError) > 0 {
+			return fmt.Errorf("failed to restart unit %d: %s", unitID, response.RestartError)
+		}
+
+		if request.Restart && response.Unit.Pid > 0 {
+			pm0.Printf("restarted unit %s (%d) with PID %d", response.Name, unitID, response.Unit.Pid)
+		}
+
 		return nil
 	})
+}
+
+// buildUpdateRequest reads the update flags into a request with the set
+// flags in its mask, they are shared with clone
+func buildUpdateRequest(ctx *command.Context, unitID uint64) (*pb.UpdateRequst, error) {
+	osCwd, err := os.Getwd()
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	labels, err := daemon.ParseLabels(ctx.CLI.StringSlice("label"))
+
+	if err != nil {
+		return nil, err
+	}
+
+	request := pb.UpdateRequst{
+		UnitId:     unitID,
+		UpdateMask: &fieldmaskpb.FieldMask{},
+		Name:       ctx.CLI.String("name"),
+		Cwd:        ctx.CLI.String("cwd"),
+		Env:        ctx.CLI.StringSlice("env"),
+		Labels:     labels,
+		User:       ctx.CLI.String("user"),
+		Group:      ctx.CLI.String("group"),
+		Groups:     ctx.CLI.StringSlice("groups"),
+
+		RestartPolicy:  ctx.CLI.String("restart-policy"),
+		RestartDelayMs: ctx.CLI.Duration("restart-delay").Milliseconds(),
+		StopSignal:     ctx.CLI.String("stop-signal"),
+		StopTimeoutMs:  ctx.CLI.Duration("stop-timeout").Milliseconds(),
+
+		HealthCmd:        ctx.CLI.String("health-cmd"),
+		HealthIntervalMs: ctx.CLI.Duration("health-interval").Milliseconds(),
+		Hooks:            ctx.CLI.StringSlice("hook"),
+
+		EnvMode:  ctx.CLI.String("env-mode"),
+		EnvAllow: ctx.CLI.StringSlice("env-allow"),
+		EnvFiles: ctx.CLI.StringSlice("env-file"),
+		Stdin:    ctx.CLI.Bool("stdin"),
+
+		Tty:       ctx.CLI.Bool("tty"),
+		StripAnsi: ctx.CLI.Bool("strip-ansi"),
+
+		MergeEnv:    !ctx.CLI.Bool("replace-env"),
+		MergeLabels: !ctx.CLI.Bool("replace-labels"),
+		Restart:     ctx.CLI.Bool("restart"),
+	}
+
+	if len(request.Cwd) > 0 && !path.IsAbs(request.Cwd) {
+		request.Cwd = path.Join(osCwd, request.Cwd)
+	}
+
+	for i, envFile := range request.EnvFiles {
+		if !path.IsAbs(envFile) {
+			request.EnvFiles[i] = path.Join(osCwd, envFile)
+		}
+	}
+
+	for _, flagField := range updateFlagFields {
+		if ctx.CLI.IsSet(flagField.flag) {
+			request.UpdateMask.Paths = append(request.UpdateMask.Paths, flagField.field)
+		}
+	}
+
+	// the args after the unit id replace the command
+	if ctx.CLI.NArg() > 1 {
+		request.Bin = ctx.CLI.Args().Get(1)
+		request.Args = ctx.CLI.Args().Slice()[2:]
+		request.UpdateMask.Paths = append(request.UpdateMask.Paths, "bin", "args")
+	}
+
+	return &request, nil
+}
+
+func writeUpdateDocument(unitID uint64, request *pb.UpdateRequst, response *pb.UpdateResponse) error {
+	document := updateDocument{
+		ID:           unitID,
+		Name:         response.Name,
+		Changes:      make([]unitFieldChangeDocument, len(response.Changes)),
+		RestartError: response.RestartError,
+	}
+
+	for i, change := range response.Changes {
+		document.Changes[i] = unitFieldChangeDocument{Field: change.Field, Old: change.Old, New: change.New}
+	}
+
+	if response.Unit != nil {
+		document.PID = response.Unit.Pid
+		document.Restarted = request.Restart &&
+			len(response.Changes) > 0 &&
+			len(response.RestartError) == 0 &&
+			response.Unit.Pid > 0
 	}
 
-	pm0.Printf("updated %s (%d)", name, unitID)
-	return nil
+	return pm0.WriteDocument(document)
 }
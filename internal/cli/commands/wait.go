@@ -0,0 +1,98 @@
+package commands
+
+import (
+	pm0 "github.com/TrixiS/pm0/internal/cli"
+	"github.com/TrixiS/pm0/internal/cli/command"
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+// waitResultDocument is a unit that met the wait condition
+type waitResultDocument struct {
+	ID       uint64 `json:"id" yaml:"id"`
+	Name     string `json:"name" yaml:"name"`
+	Status   string `json:"status" yaml:"status"`
+	Health   string `json:"health" yaml:"health"`
+	ExitCode int32  `json:"exit_code" yaml:"exit_code"`
+	Signal   string `json:"signal,omitempty" yaml:"signal,omitempty"`
+}
+
+type waitDocument struct {
+	Units []waitResultDocument `json:"units" yaml:"units"`
+}
+
+func Wait(ctx *command.Context) error {
+	unitIDs, err := pm0.ParseUnitIDsFromArgs(ctx.CLI.Args().Slice())
+
+	if err != nil {
+		return err
+	}
+
+	if len(unitIDs) == 0 {
+		return pm0.ArgumentErrorf("specify unit ids")
+	}
+
+	condition := ctx.CLI.String("for")
+
+	return ctx.Provider.WithClient(func(client pb.ProcessServiceClient) error {
+		response, err := client.Wait(ctx.CLI.Context, &pb.WaitRequest{
+			UnitIds:   unitIDs,
+			Condition: condition,
+			TimeoutMs: ctx.CLI.Duration("timeout").Milliseconds(),
+		})
+
+		if err != nil {
+			return err
+		}
+
+		if pm0.Output().Structured() {
+			document := waitDocument{Units: make([]waitResultDocument, len(response.Results))}
+
+			for i, result := range response.Results {
+				document.Units[i] = waitResultDocument{
+					ID:       result.UnitId,
+					Name:     result.Unit.Name,
+					Status:   daemon.UnitStatus(result.Unit.Status).String(),
+					Health:   result.Unit.Health,
+					ExitCode: result.ExitCode,
+					Signal:   result.Signal,
+				}
+			}
+
+			if err := pm0.WriteDocument(document); err != nil {
+				return err
+			}
+		} else {
+			for _, result := range response.Results {
+				printWaitResult(result, condition)
+			}
+		}
+
+		if condition != daemon.WaitExited {
+			return nil
+		}
+
+		// the first failed unit sets the exit code, so jobs can be run with pm0
+		for _, result := range response.Results {
+			if exitCode := pm0.ProcessExitCode(result.ExitCode, result.Signal); exitCode != pm0.ExitOK {
+				return &pm0.ExitError{Code: exitCode}
+			}
+		}
+
+		return nil
+	})
+}
+
+func printWaitResult(result *pb.WaitResult, condition string) {
+	if condition != daemon.WaitExited {
+		pm0.Printf("unit %s (%d) is %s", result.Unit.Name, result.UnitId, condition)
+		return
+	}
+
+	if len(result.Signal) > 0 {
+		pm0.Printf("unit %s (%d) was killed by %s", result.Unit.Name, result.UnitId, result.Signal)
+		return
+	}
+
+	pm0.Printf("unit %s (%d) exited with code %d", result.Unit.Name, result.UnitId, result.ExitCode)
+}
@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	maxRecvMessageSizeBytes = 8 * 1024 * 1024
+	unixAddressPrefix       = "unix://"
+	tcpAddressPrefix        = "tcp://"
+)
+
+type ConnectionOptions struct {
+	Address   string
+	TLS       bool
+	TLSCA     string
+	TLSCert   string
+	TLSKey    string
+	TLSServer string
+	Token     string
+}
+
+func (options ConnectionOptions) useTLS() bool {
+	return options.TLS || len(options.TLSCA) > 0 || len(options.TLSCert) > 0
+}
+
+func (options ConnectionOptions) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: options.TLSServer,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(options.TLSCA) > 0 {
+		caPEM, err := os.ReadFile(options.TLSCA)
+
+		if err != nil {
+			return nil, err
+		}
+
+		rootCAs := x509.NewCertPool()
+
+		if !rootCAs.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in %s", options.TLSCA)
+		}
+
+		tlsConfig.RootCAs = rootCAs
+	}
+
+	if len(options.TLSCert) > 0 {
+		certificate, err := tls.LoadX509KeyPair(options.TLSCert, options.TLSKey)
+
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// Target converts an address to a grpc target. Addresses without a scheme
+// are treated as tcp addresses, absolute paths as unix sockets
+func (options ConnectionOptions) Target() string {
+	address := options.Address
+
+	if strings.HasPrefix(address, "/") {
+		return unixAddressPrefix + address
+	}
+
+	if tcpAddress, ok := strings.CutPrefix(address, tcpAddressPrefix); ok {
+		return tcpAddress
+	}
+
+	return address
+}
+
+type tokenCredentials struct {
+	token string
+}
+
+func (c tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+func Dial(options ConnectionOptions) (*grpc.ClientConn, error) {
+	dialOptions := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMessageSizeBytes)),
+	}
+
+	if options.useTLS() {
+		tlsConfig, err := options.tlsConfig()
+
+		if err != nil {
+			return nil, err
+		}
+
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if len(options.Token) > 0 {
+		if !options.useTLS() {
+			return nil, fmt.Errorf("tokens are only sent over tls, set --tls-ca to connect to %s with a token", options.Address)
+		}
+
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(tokenCredentials{options.Token}))
+	}
+
+	return grpc.NewClient(options.Target(), dialOptions...)
+}
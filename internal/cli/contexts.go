@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/asdine/storm/v3"
+)
+
+const (
+	TransportUnix = "unix"
+	TransportTCP  = "tcp"
+	TransportTLS  = "tls"
+
+	settingsBucket        = "settings"
+	currentContextSetting = "current_context"
+)
+
+type ContextModel struct {
+	Name      string `storm:"id"`
+	Address   string
+	Transport string
+	TLSCA     string
+	TLSCert   string
+	TLSKey    string
+	TLSServer string
+	Token     string
+	Selectors []string
+}
+
+func ParseTransport(transport string) (string, error) {
+	switch transport {
+	case TransportUnix, TransportTCP, TransportTLS:
+		return transport, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown transport %q, expected one of %s",
+			transport,
+			strings.Join([]string{TransportUnix, TransportTCP, TransportTLS}, ", "),
+		)
+	}
+}
+
+func (model *ContextModel) ConnectionOptions() ConnectionOptions {
+	options := ConnectionOptions{
+		Address:   model.Address,
+		TLS:       model.Transport == TransportTLS,
+		TLSCA:     model.TLSCA,
+		TLSCert:   model.TLSCert,
+		TLSKey:    model.TLSKey,
+		TLSServer: model.TLSServer,
+		Token:     model.Token,
+	}
+
+	if model.Transport == TransportUnix && !strings.HasPrefix(options.Address, unixAddressPrefix) {
+		options.Address = unixAddressPrefix + options.Address
+	}
+
+	return options
+}
+
+func GetCurrentContextName(db *storm.DB) (string, error) {
+	var name string
+
+	if err := db.Get(settingsBucket, currentContextSetting, &name); err != nil {
+		if errors.Is(err, storm.ErrNotFound) {
+			return "", nil
+		}
+
+		return "", err
+	}
+
+	return name, nil
+}
+
+func SetCurrentContextName(db *storm.DB, name string) error {
+	if len(name) == 0 {
+		err := db.Delete(settingsBucket, currentContextSetting)
+
+		if errors.Is(err, storm.ErrNotFound) {
+			return nil
+		}
+
+		return err
+	}
+
+	return db.Set(settingsBucket, currentContextSetting, name)
+}
+
+// LoadContext returns the context with the given name or the current one if
+// the name is empty. Nil is returned when no context is selected
+func LoadContext(db *storm.DB, name string) (*ContextModel, error) {
+	explicit := len(name) > 0
+
+	if !explicit {
+		currentName, err := GetCurrentContextName(db)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(currentName) == 0 {
+			return nil, nil
+		}
+
+		name = currentName
+	}
+
+	var model ContextModel
+
+	if err := db.One("Name", name, &model); err != nil {
+		if errors.Is(err, storm.ErrNotFound) && !explicit {
+			return nil, nil
+		}
+
+		if errors.Is(err, storm.ErrNotFound) {
+			return nil, fmt.Errorf("context %s not found", name)
+		}
+
+		return nil, err
+	}
+
+	return &model, nil
+}
@@ -0,0 +1,122 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/jedib0t/go-pretty/v6/text"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes of the CLI
+const (
+	ExitOK                = 0
+	ExitFailure           = 1 // any other error
+	ExitInvalidArguments  = 2
+	ExitDaemonUnreachable = 3
+	ExitNotFound          = 4 // the unit, template, secret or context doesn't exist, or ls matched no units
+	ExitPartialFailure    = 5 // some of the units failed, the others succeeded
+	ExitTimeout           = 6
+)
+
+// ExitError sets the exit code of the CLI. An ExitError without Err only
+// sets the code, the failures were already printed
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("exit code %d", e.Code)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+// ArgumentErrorf returns an error exiting with ExitInvalidArguments
+func ArgumentErrorf(format string, args ...any) error {
+	return &ExitError{Code: ExitInvalidArguments, Err: fmt.Errorf(format, args...)}
+}
+
+// ExitCode maps the error of a command to the exit code of the CLI
+func ExitCode(err error) int {
+	var exitErr *ExitError
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrEmptyUnits):
+		return ExitNotFound
+	case errors.As(err, &exitErr):
+		return exitErr.Code
+	}
+
+	return StatusExitCode(status.Code(err))
+}
+
+// StatusExitCode maps grpc status codes of the daemon responses to exit codes
+func StatusExitCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return ExitOK
+	case codes.Unavailable:
+		return ExitDaemonUnreachable
+	case codes.NotFound:
+		return ExitNotFound
+	case codes.InvalidArgument:
+		return ExitInvalidArguments
+	case codes.DeadlineExceeded:
+		return ExitTimeout
+	default:
+		return ExitFailure
+	}
+}
+
+// ProcessExitCode is the exit code of the CLI for an exited process. It
+// follows the shell convention of 128 + the signal number for the processes
+// killed by a signal
+func ProcessExitCode(exitCode int32, signal string) int {
+	if len(signal) > 0 {
+		if signalNumber, err := daemon.ParseSignal(signal); err == nil {
+			return 128 + int(signalNumber)
+		}
+
+		return ExitFailure
+	}
+
+	if exitCode < 0 || exitCode > 255 {
+		return ExitFailure
+	}
+
+	return int(exitCode)
+}
+
+// Eprintf prints a message to stderr
+func Eprintf(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, text.FgHiCyan.Sprint("[PM0] ")+format+"\n", args...)
+}
+
+// PrintError prints the error of a command to stderr. Daemon errors are
+// printed without the grpc prefix
+func PrintError(err error) {
+	var exitErr *ExitError
+
+	if errors.As(err, &exitErr) && exitErr.Err == nil {
+		return
+	}
+
+	if grpcStatus, ok := status.FromError(err); ok {
+		Eprintf("%s", grpcStatus.Message())
+		return
+	}
+
+	Eprintf("%s", err)
+}
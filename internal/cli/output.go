@@ -1,30 +1,99 @@
 package cli
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/TrixiS/pm0/internal/daemon"
 	"github.com/jedib0t/go-pretty/v6/text"
+	"gopkg.in/yaml.v3"
 )
 
-const tableNoneString = "None"
+const TableNoneString = "None"
 
-var (
-	pm0OutputPrefix     = text.FgHiCyan.Sprint("[PM0] ")
-	runningStatusString = text.FgGreen.Sprint("Running")
-	exitedStatusString  = text.FgWhite.Sprint("Exited")
-	failedStatusString  = text.FgRed.Sprint("Failed")
-	stoppedStatusString = text.FgYellow.Sprint("Stopped")
+// OutputFormat is the format of the command output, set with the global --output flag
+type OutputFormat string
+
+const (
+	OutputTable OutputFormat = "table"
+	OutputWide  OutputFormat = "wide"
+	OutputJSON  OutputFormat = "json"
+	OutputYAML  OutputFormat = "yaml"
 )
 
+var outputFormat = OutputTable
+
+func ParseOutputFormat(format string) (OutputFormat, error) {
+	switch outputFormat := OutputFormat(format); outputFormat {
+	case OutputTable, OutputWide, OutputJSON, OutputYAML:
+		return outputFormat, nil
+	default:
+		return "", fmt.Errorf("unknown output format %q, expected table, wide, json or yaml", format)
+	}
+}
+
+func SetOutputFormat(format OutputFormat) {
+	outputFormat = format
+}
+
+func Output() OutputFormat {
+	return outputFormat
+}
+
+// Structured reports if the output is json or yaml documents instead of tables and messages
+func (format OutputFormat) Structured() bool {
+	return format == OutputJSON || format == OutputYAML
+}
+
+// SetColors enables or disables the ANSI colors of the output. Colors are
+// disabled when NO_COLOR is set to a non-empty value or the output is structured
+func SetColors(enabled bool) {
+	if enabled && len(os.Getenv("NO_COLOR")) == 0 && !outputFormat.Structured() {
+		text.EnableColors()
+		return
+	}
+
+	text.DisableColors()
+}
+
+// DocumentEncoder writes json or yaml documents in the output format
+type DocumentEncoder interface {
+	Encode(document any) error
+}
+
+// NewDocumentEncoder returns an encoder of json documents, one per line
+// unless indent is set, or of yaml documents separated by ---
+func NewDocumentEncoder(w io.Writer, indent bool) DocumentEncoder {
+	if outputFormat == OutputYAML {
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		return encoder
+	}
+
+	encoder := json.NewEncoder(w)
+
+	if indent {
+		encoder.SetIndent("", "  ")
+	}
+
+	return encoder
+}
+
+// WriteDocument writes a single document to stdout in the output format
+func WriteDocument(document any) error {
+	return NewDocumentEncoder(os.Stdout, true).Encode(document)
+}
+
 func Printf(format string, args ...any) {
-	fmt.Printf(pm0OutputPrefix+format+"\n", args...)
+	fmt.Printf(text.FgHiCyan.Sprint("[PM0] ")+format+"\n", args...)
 }
 
 func FormatUnitUptime(startedAt int64, status daemon.UnitStatus) string {
 	if status != daemon.UnitStatusRunning {
-		return tableNoneString
+		return TableNoneString
 	}
 
 	startedAtTime := time.Unix(startedAt, 0)
@@ -36,13 +105,13 @@ func FormatUnitUptime(startedAt int64, status daemon.UnitStatus) string {
 func FormatUnitStatus(unitStatus daemon.UnitStatus) string {
 	switch unitStatus {
 	case daemon.UnitStatusRunning:
-		return runningStatusString
+		return text.FgGreen.Sprint("Running")
 	case daemon.UnitStatusExited:
-		return exitedStatusString
+		return text.FgWhite.Sprint("Exited")
 	case daemon.UnitStatusFailed:
-		return failedStatusString
+		return text.FgRed.Sprint("Failed")
 	case daemon.UnitStatusStopped:
-		return stoppedStatusString
+		return text.FgYellow.Sprint("Stopped")
 	default:
 		return "Unknown"
 	}
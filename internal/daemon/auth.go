@@ -0,0 +1,377 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type Role string
+
+const (
+	RoleRead  Role = "read"
+	RoleAdmin Role = "admin"
+)
+
+const authorizationMetadataKey = "authorization"
+
+var readMethods = map[string]bool{
+	pb.ProcessService_List_FullMethodName:         true,
+	pb.ProcessService_Show_FullMethodName:         true,
+	pb.ProcessService_Logs_FullMethodName:         true,
+	pb.ProcessService_History_FullMethodName:      true,
+	pb.ProcessService_Events_FullMethodName:       true,
+	pb.ProcessService_SecretList_FullMethodName:   true,
+	pb.ProcessService_TemplateList_FullMethodName: true,
+	pb.ProcessService_Wait_FullMethodName:         true,
+}
+
+type principalContextKey struct{}
+
+// principalFromContext describes how a network caller was authenticated, like
+// "token ci", empty for the other callers
+func principalFromContext(ctx context.Context) string {
+	principal, _ := ctx.Value(principalContextKey{}).(string)
+	return principal
+}
+
+type Token struct {
+	Name  string
+	Value string
+	Role  Role
+}
+
+func ParseRole(role string) (Role, error) {
+	switch Role(role) {
+	case RoleRead, RoleAdmin:
+		return Role(role), nil
+	default:
+		return "", fmt.Errorf("unknown role %q, expected %s or %s", role, RoleRead, RoleAdmin)
+	}
+}
+
+// ReadTokensFile reads tokens from lines formatted as "<token> <role> [name]".
+// Empty lines and lines starting with # are ignored
+func ReadTokensFile(tokensFilepath string) ([]Token, error) {
+	tokensFile, err := os.Open(tokensFilepath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer tokensFile.Close()
+
+	var tokens []Token
+	scanner := bufio.NewScanner(tokensFile)
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("%s:%d: expected a token and a role", tokensFilepath, lineNumber)
+		}
+
+		role, err := ParseRole(fields[1])
+
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", tokensFilepath, lineNumber, err)
+		}
+
+		token := Token{Value: fields[0], Role: role, Name: fmt.Sprintf("line %d", lineNumber)}
+
+		if len(fields) > 2 {
+			token.Name = fields[2]
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, scanner.Err()
+}
+
+// LoadServerTLSConfig loads the daemon certificate. Client certificates are
+// required and verified when clientCAFilepath is not empty
+func LoadServerTLSConfig(
+	certFilepath string,
+	keyFilepath string,
+	clientCAFilepath string,
+) (*tls.Config, error) {
+	certificate, err := tls.LoadX509KeyPair(certFilepath, keyFilepath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if len(clientCAFilepath) == 0 {
+		return tlsConfig, nil
+	}
+
+	clientCAPEM, err := os.ReadFile(clientCAFilepath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	clientCAs := x509.NewCertPool()
+
+	if !clientCAs.AppendCertsFromPEM(clientCAPEM) {
+		return nil, fmt.Errorf("no certificates found in %s", clientCAFilepath)
+	}
+
+	tlsConfig.ClientCAs = clientCAs
+	tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	return tlsConfig, nil
+}
+
+// Authenticator identifies the callers that are not connected over the unix
+// socket: by their bearer token if tokens are configured, then by the role of
+// their client certificate. Without both only the callers of loopback
+// listeners are accepted, as admins
+type Authenticator struct {
+	tokens         []Token
+	tlsClientRoles map[string]Role
+	mu             sync.RWMutex
+}
+
+func NewAuthenticator(tokens []Token, tlsClientRoles map[string]Role) *Authenticator {
+	return &Authenticator{tokens: tokens, tlsClientRoles: tlsClientRoles}
+}
+
+func (a *Authenticator) SetTokens(tokens []Token, tlsClientRoles map[string]Role) {
+	a.mu.Lock()
+	a.tokens = tokens
+	a.tlsClientRoles = tlsClientRoles
+	a.mu.Unlock()
+}
+
+func (a *Authenticator) findToken(value string) *Token {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	for _, token := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token.Value), []byte(value)) == 1 {
+			return &token
+		}
+	}
+
+	return nil
+}
+
+func (a *Authenticator) hasTokens() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return len(a.tokens) > 0
+}
+
+func (a *Authenticator) tlsClientRole(commonName string) (Role, bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	role, ok := a.tlsClientRoles[commonName]
+	return role, ok
+}
+
+// connInfo is the connection of a network caller
+type connInfo struct {
+	localAddr      net.Addr
+	verifiedChains [][]*x509.Certificate
+}
+
+func grpcConnInfo(ctx context.Context) connInfo {
+	var conn connInfo
+
+	if p, ok := peer.FromContext(ctx); ok {
+		conn.localAddr = p.LocalAddr
+
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+			conn.verifiedChains = tlsInfo.State.VerifiedChains
+		}
+	}
+
+	return conn
+}
+
+func httpConnInfo(r *http.Request) connInfo {
+	var conn connInfo
+	conn.localAddr, _ = r.Context().Value(http.LocalAddrContextKey).(net.Addr)
+
+	if r.TLS != nil {
+		conn.verifiedChains = r.TLS.VerifiedChains
+	}
+
+	return conn
+}
+
+// clientCommonName returns the common name of the verified client certificate
+func (c connInfo) clientCommonName() (string, bool) {
+	if len(c.verifiedChains) == 0 || len(c.verifiedChains[0]) == 0 {
+		return "", false
+	}
+
+	return c.verifiedChains[0][0].Subject.CommonName, true
+}
+
+func (c connInfo) isLoopback() bool {
+	addr, ok := c.localAddr.(*net.TCPAddr)
+	return ok && addr.IP.IsLoopback()
+}
+
+// identify returns the principal and the role of a network caller
+func (a *Authenticator) identify(ctx context.Context, conn connInfo) (string, Role, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if values := md.Get(authorizationMetadataKey); len(values) > 0 {
+		value, ok := strings.CutPrefix(values[0], "Bearer ")
+
+		if !ok {
+			return "", "", status.Error(codes.Unauthenticated, "malformed authorization metadata")
+		}
+
+		token := a.findToken(value)
+
+		if token == nil {
+			return "", "", status.Error(codes.Unauthenticated, "invalid bearer token")
+		}
+
+		return "token " + token.Name, token.Role, nil
+	}
+
+	if a.hasTokens() {
+		return "", "", status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+
+	if commonName, ok := conn.clientCommonName(); ok {
+		role, ok := a.tlsClientRole(commonName)
+
+		if !ok {
+			return "", "", status.Errorf(
+				codes.PermissionDenied,
+				"client certificate %q has no role in auth.tls_client_roles",
+				commonName,
+			)
+		}
+
+		return "certificate " + commonName, role, nil
+	}
+
+	if conn.isLoopback() {
+		return "loopback " + conn.localAddr.String(), RoleAdmin, nil
+	}
+
+	return "", "", status.Error(
+		codes.Unauthenticated,
+		"the caller could not be identified, authenticate with a token or a client certificate",
+	)
+}
+
+// authenticate returns the context with the network caller and checks that
+// its role may call the method
+func (a *Authenticator) authenticate(ctx context.Context, fullMethod string, conn connInfo) (context.Context, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		if _, ok := p.AuthInfo.(PeerCredAuthInfo); ok {
+			return ctx, nil
+		}
+	}
+
+	principal, role, err := a.identify(ctx, conn)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if role != RoleAdmin && !readMethods[fullMethod] {
+		return nil, status.Errorf(
+			codes.PermissionDenied,
+			"%s with role %s can't call %s",
+			principal,
+			role,
+			fullMethod,
+		)
+	}
+
+	ctx = context.WithValue(ctx, principalContextKey{}, principal)
+	return ContextWithCaller(ctx, &Caller{Network: true}), nil
+}
+
+// authenticateRequest checks an HTTP request as a call of the grpc method,
+// the tokens and roles are the ones of the grpc listeners
+func (a *Authenticator) authenticateRequest(r *http.Request, fullMethod string) (context.Context, error) {
+	ctx := r.Context()
+
+	if authorization := r.Header.Get("Authorization"); len(authorization) > 0 {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationMetadataKey, authorization))
+	}
+
+	return a.authenticate(ctx, fullMethod, httpConnInfo(r))
+}
+
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req any,
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (any, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod, grpcConnInfo(ctx))
+
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(
+		srv any,
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler,
+	) error {
+		ctx, err := a.authenticate(stream.Context(), info.FullMethod, grpcConnInfo(stream.Context()))
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
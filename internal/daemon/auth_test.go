@@ -0,0 +1,108 @@
+package daemon
+
+import (
+	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net"
+	"testing"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	testTokens = []Token{
+		{Name: "deploy", Value: "admin-token", Role: RoleAdmin},
+		{Name: "grafana", Value: "read-token", Role: RoleRead},
+	}
+
+	testTLSClientRoles = map[string]Role{
+		"deploy":  RoleAdmin,
+		"grafana": RoleRead,
+	}
+
+	loopbackAddr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 7777}
+	networkAddr  = &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 7777}
+)
+
+func clientCertConn(localAddr net.Addr, commonName string) connInfo {
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	return connInfo{localAddr: localAddr, verifiedChains: [][]*x509.Certificate{{certificate}}}
+}
+
+func TestAuthenticate(t *testing.T) {
+	stop := pb.ProcessService_Stop_FullMethodName
+	list := pb.ProcessService_List_FullMethodName
+
+	tests := []struct {
+		name          string
+		tokens        bool // the authenticator has the test tokens, otherwise the client roles
+		authorization string
+		peerCred      bool
+		conn          connInfo
+		method        string
+		principal     string
+		code          codes.Code
+	}{
+		{"admin token", true, "Bearer admin-token", false, connInfo{localAddr: networkAddr}, stop, "token deploy", codes.OK},
+		{"read token reads", true, "Bearer read-token", false, connInfo{localAddr: networkAddr}, list, "token grafana", codes.OK},
+		{"read token changes", true, "Bearer read-token", false, connInfo{localAddr: networkAddr}, stop, "", codes.PermissionDenied},
+		{"invalid token", true, "Bearer nope", false, connInfo{localAddr: loopbackAddr}, list, "", codes.Unauthenticated},
+		{"malformed authorization", true, "admin-token", false, connInfo{localAddr: networkAddr}, list, "", codes.Unauthenticated},
+		{"missing token on loopback", true, "", false, connInfo{localAddr: loopbackAddr}, list, "", codes.Unauthenticated},
+		{"certificate without the token", true, "", false, clientCertConn(networkAddr, "deploy"), list, "", codes.Unauthenticated},
+		{"peer credentials", true, "", true, connInfo{}, stop, "", codes.OK},
+		{"admin certificate", false, "", false, clientCertConn(networkAddr, "deploy"), stop, "certificate deploy", codes.OK},
+		{"read certificate reads", false, "", false, clientCertConn(networkAddr, "grafana"), list, "certificate grafana", codes.OK},
+		{"read certificate changes", false, "", false, clientCertConn(networkAddr, "grafana"), stop, "", codes.PermissionDenied},
+		{"certificate without a role", false, "", false, clientCertConn(loopbackAddr, "intruder"), list, "", codes.PermissionDenied},
+		{"loopback", false, "", false, connInfo{localAddr: loopbackAddr}, stop, "loopback 127.0.0.1:7777", codes.OK},
+		{"unidentified", false, "", false, connInfo{localAddr: networkAddr}, list, "", codes.Unauthenticated},
+		{"unknown listener", false, "", false, connInfo{}, list, "", codes.Unauthenticated},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			authenticator := NewAuthenticator(nil, testTLSClientRoles)
+
+			if test.tokens {
+				authenticator = NewAuthenticator(testTokens, nil)
+			}
+
+			ctx := context.Background()
+
+			if len(test.authorization) > 0 {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(authorizationMetadataKey, test.authorization))
+			}
+
+			if test.peerCred {
+				ctx = peer.NewContext(ctx, &peer.Peer{AuthInfo: PeerCredAuthInfo{Caller: Caller{UID: 1000}}})
+			}
+
+			ctx, err := authenticator.authenticate(ctx, test.method, test.conn)
+
+			if code := status.Code(err); code != test.code {
+				t.Fatalf("code %s, expected %s: %v", code, test.code, err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if principal := principalFromContext(ctx); principal != test.principal {
+				t.Fatalf("principal %q, expected %q", principal, test.principal)
+			}
+
+			// the network callers manage every unit, the peer credentials are kept
+			caller, _ := ctx.Value(callerContextKey{}).(*Caller)
+
+			if networkCaller := caller != nil && caller.Network; networkCaller == test.peerCred {
+				t.Fatalf("caller %+v of a network caller %t", caller, !test.peerCred)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os/user"
+	"strconv"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+const peerCredAuthType = "peercred"
+
+type Caller struct {
+	UID uint32
+	GID uint32
+	PID int32
+	// Network callers were authenticated by a token, a client certificate or
+	// a loopback listener instead of their uid. They manage the units of
+	// every user and theirs run with the daemon credentials by default
+	Network bool
+}
+
+// IsAdmin reports whether the caller manages every unit. Callers that could
+// not be identified are denied
+func (c *Caller) IsAdmin() bool {
+	return c != nil && (c.Network || c.UID == 0)
+}
+
+// CanAccess reports whether the caller may see and manage the unit
+func (c *Caller) CanAccess(model *UnitModel) bool {
+	return c.IsAdmin() || (c != nil && c.UID == model.OwnerUID)
+}
+
+type PeerCredAuthInfo struct {
+	credentials.CommonAuthInfo
+	Caller Caller
+}
+
+func (PeerCredAuthInfo) AuthType() string {
+	return peerCredAuthType
+}
+
+type insecureAuthInfo struct {
+	credentials.CommonAuthInfo
+}
+
+func (insecureAuthInfo) AuthType() string {
+	return "insecure"
+}
+
+// PeerCredentials identifies callers connected over a unix socket by their
+// SO_PEERCRED credentials. Other connections go through TLS if it's set
+type PeerCredentials struct {
+	TLS credentials.TransportCredentials
+}
+
+func (c PeerCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	unixConn, ok := conn.(*net.UnixConn)
+
+	if !ok && c.TLS != nil {
+		return c.TLS.ServerHandshake(conn)
+	}
+
+	if !ok {
+		return conn, insecureAuthInfo{
+			CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+		}, nil
+	}
+
+	caller, err := readPeerCaller(unixConn)
+
+	if err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	authInfo := PeerCredAuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{
+			SecurityLevel: credentials.PrivacyAndIntegrity,
+		},
+		Caller: caller,
+	}
+
+	return conn, authInfo, nil
+}
+
+func (PeerCredentials) ClientHandshake(
+	ctx context.Context,
+	authority string,
+	conn net.Conn,
+) (net.Conn, credentials.AuthInfo, error) {
+	return conn, insecureAuthInfo{
+		CommonAuthInfo: credentials.CommonAuthInfo{SecurityLevel: credentials.NoSecurity},
+	}, nil
+}
+
+func (PeerCredentials) Info() credentials.ProtocolInfo {
+	return credentials.ProtocolInfo{SecurityProtocol: peerCredAuthType}
+}
+
+func (c PeerCredentials) Clone() credentials.TransportCredentials {
+	if c.TLS != nil {
+		return PeerCredentials{TLS: c.TLS.Clone()}
+	}
+
+	return c
+}
+
+func (PeerCredentials) OverrideServerName(string) error {
+	return nil
+}
+
+type callerContextKey struct{}
+
+// ContextWithCaller returns the context of a call made by the caller, for the
+// callers that aren't connected over the unix socket like in-process ones
+func ContextWithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerContextKey{}, caller)
+}
+
+// callerFromContext returns nil when the caller could not be identified
+func callerFromContext(ctx context.Context) *Caller {
+	if caller, ok := ctx.Value(callerContextKey{}).(*Caller); ok {
+		return caller
+	}
+
+	p, ok := peer.FromContext(ctx)
+
+	if !ok {
+		return nil
+	}
+
+	authInfo, ok := p.AuthInfo.(PeerCredAuthInfo)
+
+	if !ok {
+		return nil
+	}
+
+	return &authInfo.Caller
+}
+
+// ownerFromContext returns the uid that owns the secrets and templates the
+// caller manages. Network callers manage the ones of root
+func ownerFromContext(ctx context.Context) uint32 {
+	if caller := callerFromContext(ctx); caller != nil {
+		return caller.UID
+	}
+
+	return 0
+}
+
+// ownedID is the storage id of a secret or a template, they are namespaced by their owner
+func ownedID(ownerUID uint32, name string) string {
+	return strconv.FormatUint(uint64(ownerUID), 10) + "/" + name
+}
+
+// describeCaller names the caller for the unit history
+func describeCaller(ctx context.Context) string {
+	if principal := principalFromContext(ctx); len(principal) > 0 {
+		return principal
+	}
+
+	if caller := callerFromContext(ctx); caller != nil {
+		uid := strconv.FormatUint(uint64(caller.UID), 10)
+
+		if u, err := user.LookupId(uid); err == nil {
+			return fmt.Sprintf("%s (uid %s)", u.Username, uid)
+		}
+
+		return "uid " + uid
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		return p.Addr.String()
+	}
+
+	return "unknown"
+}
@@ -0,0 +1,392 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	RestartPolicyOnFailure = "on-failure"
+	RestartPolicyAlways    = "always"
+	RestartPolicyNever     = "never"
+
+	DefaultDBFilename   = "pm0_daemon.db"
+	DefaultLogsDirname  = "logs"
+	redactedConfigValue = "<redacted>"
+)
+
+type Duration time.Duration
+
+func (d Duration) MarshalYAML() (any, error) {
+	return time.Duration(d).String(), nil
+}
+
+func (d *Duration) UnmarshalYAML(value *yaml.Node) error {
+	duration, err := time.ParseDuration(value.Value)
+
+	if err != nil {
+		return fmt.Errorf("line %d: %w", value.Line, err)
+	}
+
+	*d = Duration(duration)
+	return nil
+}
+
+type FileMode os.FileMode
+
+func (m FileMode) MarshalYAML() (any, error) {
+	return fmt.Sprintf("%#o", m), nil
+}
+
+func (m *FileMode) UnmarshalYAML(value *yaml.Node) error {
+	mode, err := strconv.ParseUint(value.Value, 8, 32)
+
+	if err != nil {
+		return fmt.Errorf("line %d: file mode should be an octal number: %w", value.Line, err)
+	}
+
+	*m = FileMode(mode)
+	return nil
+}
+
+type LogsConfig struct {
+	Dir      string   `yaml:"dir"`
+	FileMode FileMode `yaml:"file_mode"`
+}
+
+type MetricsConfig struct {
+	Listen string `yaml:"listen"`
+}
+
+type DashboardConfig struct {
+	Listen string `yaml:"listen"`
+}
+
+type GatewayConfig struct {
+	Listen string `yaml:"listen"`
+}
+
+type TokenConfig struct {
+	Name  string `yaml:"name"`
+	Token string `yaml:"token"`
+	Role  Role   `yaml:"role"`
+}
+
+type AuthConfig struct {
+	TLSCert     string        `yaml:"tls_cert"`
+	TLSKey      string        `yaml:"tls_key"`
+	TLSClientCA string        `yaml:"tls_client_ca"`
+	TokensFile  string        `yaml:"tokens_file"`
+	Tokens      []TokenConfig `yaml:"tokens"`
+	// TLSClientRoles are the roles of the client certificates by their
+	// common name, used when no tokens are configured
+	TLSClientRoles map[string]Role `yaml:"tls_client_roles"`
+}
+
+type UnitDefaultsConfig struct {
+	RestartPolicy  string   `yaml:"restart_policy"`
+	RestartDelay   Duration `yaml:"restart_delay"`
+	StopSignal     string   `yaml:"stop_signal"`
+	StopTimeout    Duration `yaml:"stop_timeout"`
+	HealthInterval Duration `yaml:"health_interval"`
+	EnvMode        string   `yaml:"env_mode"`
+	EnvAllow       []string `yaml:"env_allow"`
+}
+
+// HookConfig is a webhook if URL is set or a shell command otherwise
+type HookConfig struct {
+	Phase        string   `yaml:"phase"`
+	URL          string   `yaml:"url,omitempty"`
+	Command      string   `yaml:"command,omitempty"`
+	Secret       string   `yaml:"secret,omitempty"`
+	Retries      int      `yaml:"retries,omitempty"`
+	Timeout      Duration `yaml:"timeout,omitempty"`
+	Selectors    []string `yaml:"selectors,omitempty"`
+	FailuresOnly bool     `yaml:"failures_only,omitempty"`
+}
+
+type HistoryConfig struct {
+	Events   int `yaml:"events"`
+	LogLines int `yaml:"log_lines"`
+}
+
+// SecretsConfig is the key file encrypting the secrets. It should be kept
+// outside the data dir backups, a relative path is relative to the data dir
+type SecretsConfig struct {
+	KeyFile string `yaml:"key_file"`
+}
+
+type ShutdownConfig struct {
+	Detach      bool     `yaml:"detach"`
+	GracePeriod Duration `yaml:"grace_period"`
+}
+
+type Config struct {
+	Listen    []string           `yaml:"listen"`
+	DataDir   string             `yaml:"data_dir"`
+	DBFile    string             `yaml:"db_file"`
+	Logs      LogsConfig         `yaml:"logs"`
+	Metrics   MetricsConfig      `yaml:"metrics"`
+	Dashboard DashboardConfig    `yaml:"dashboard"`
+	Gateway   GatewayConfig      `yaml:"gateway"`
+	Auth      AuthConfig         `yaml:"auth"`
+	Units     UnitDefaultsConfig `yaml:"units"`
+	Shutdown  ShutdownConfig     `yaml:"shutdown"`
+	History   HistoryConfig      `yaml:"history"`
+	Hooks     []HookConfig       `yaml:"hooks"`
+	Secrets   SecretsConfig      `yaml:"secrets"`
+}
+
+func DefaultConfig(dataDirpath string, socketFilepath string) Config {
+	return Config{
+		Listen:  []string{"unix://" + socketFilepath},
+		DataDir: dataDirpath,
+		DBFile:  DefaultDBFilename,
+		Logs: LogsConfig{
+			FileMode: logFilePerm,
+		},
+		Units: UnitDefaultsConfig{
+			RestartPolicy:  RestartPolicyOnFailure,
+			RestartDelay:   Duration(time.Second * 5),
+			StopSignal:     "SIGTERM",
+			StopTimeout:    Duration(time.Second * 10),
+			HealthInterval: Duration(time.Second * 10),
+			EnvMode:        EnvModeAllow,
+			EnvAllow:       []string{"PATH", "LANG", "TZ"},
+		},
+		Shutdown: ShutdownConfig{
+			GracePeriod: Duration(time.Second * 5),
+		},
+		History: HistoryConfig{
+			Events:   100,
+			LogLines: 20,
+		},
+	}
+}
+
+// LoadConfig reads the config file over the defaults. A missing file is only
+// an error if it must exist
+func LoadConfig(configFilepath string, mustExist bool, defaults Config) (Config, error) {
+	config := defaults
+	configBytes, err := os.ReadFile(configFilepath)
+
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) && !mustExist {
+			return config, nil
+		}
+
+		return config, err
+	}
+
+	if err := yaml.Unmarshal(configBytes, &config); err != nil {
+		return config, fmt.Errorf("%s: %w", configFilepath, err)
+	}
+
+	return config, nil
+}
+
+func ParseRestartPolicy(policy string) (string, error) {
+	switch policy {
+	case RestartPolicyOnFailure, RestartPolicyAlways, RestartPolicyNever:
+		return policy, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown restart policy %q, expected %s, %s or %s",
+			policy,
+			RestartPolicyOnFailure,
+			RestartPolicyAlways,
+			RestartPolicyNever,
+		)
+	}
+}
+
+func (c *Config) Validate() error {
+	if len(c.Listen) == 0 {
+		return errors.New("at least one listen address is required")
+	}
+
+	for _, address := range c.Listen {
+		if _, _, err := ParseListenAddress(address); err != nil {
+			return err
+		}
+	}
+
+	if _, err := ParseRestartPolicy(c.Units.RestartPolicy); err != nil {
+		return fmt.Errorf("units.restart_policy: %w", err)
+	}
+
+	if _, err := ParseSignal(c.Units.StopSignal); err != nil {
+		return fmt.Errorf("units.stop_signal: %w", err)
+	}
+
+	if c.Units.HealthInterval <= 0 {
+		return errors.New("units.health_interval should be positive")
+	}
+
+	if _, err := ParseEnvMode(c.Units.EnvMode); err != nil {
+		return fmt.Errorf("units.env_mode: %w", err)
+	}
+
+	if c.History.Events <= 0 {
+		return errors.New("history.events should be positive")
+	}
+
+	if c.History.LogLines < 0 {
+		return errors.New("history.log_lines can't be negative")
+	}
+
+	for i, hook := range c.Hooks {
+		if err := hook.Validate(); err != nil {
+			return fmt.Errorf("hooks[%d]: %w", i, err)
+		}
+	}
+
+	for i, token := range c.Auth.Tokens {
+		if len(token.Token) == 0 {
+			return fmt.Errorf("auth.tokens[%d]: token is empty", i)
+		}
+
+		if _, err := ParseRole(string(token.Role)); err != nil {
+			return fmt.Errorf("auth.tokens[%d]: %w", i, err)
+		}
+	}
+
+	for commonName, role := range c.Auth.TLSClientRoles {
+		if _, err := ParseRole(string(role)); err != nil {
+			return fmt.Errorf("auth.tls_client_roles[%s]: %w", commonName, err)
+		}
+	}
+
+	if (len(c.Auth.TLSCert) > 0) != (len(c.Auth.TLSKey) > 0) {
+		return errors.New("auth.tls_cert and auth.tls_key should be set together")
+	}
+
+	return nil
+}
+
+func (c *Config) LogsDirpath() string {
+	if len(c.Logs.Dir) > 0 {
+		return c.Logs.Dir
+	}
+
+	return path.Join(c.DataDir, DefaultLogsDirname)
+}
+
+func (c *Config) DBFilepath() string {
+	if path.IsAbs(c.DBFile) {
+		return c.DBFile
+	}
+
+	return path.Join(c.DataDir, c.DBFile)
+}
+
+func (c *Config) SecretKeyFilepath() string {
+	keyFile := c.Secrets.KeyFile
+
+	if len(keyFile) == 0 {
+		keyFile = DefaultSecretKeyFilename
+	}
+
+	if path.IsAbs(keyFile) {
+		return keyFile
+	}
+
+	return path.Join(c.DataDir, keyFile)
+}
+
+// LoadTokens merges the inline tokens with the tokens file
+func (c *Config) LoadTokens() ([]Token, error) {
+	tokens := make([]Token, 0, len(c.Auth.Tokens))
+
+	for i, token := range c.Auth.Tokens {
+		name := token.Name
+
+		if len(name) == 0 {
+			name = fmt.Sprintf("auth.tokens[%d]", i)
+		}
+
+		tokens = append(tokens, Token{Name: name, Value: token.Token, Role: token.Role})
+	}
+
+	if len(c.Auth.TokensFile) == 0 {
+		return tokens, nil
+	}
+
+	fileTokens, err := ReadTokensFile(c.Auth.TokensFile)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tokens, fileTokens...), nil
+}
+
+// Redacted returns a copy of the config that is safe to show to clients
+func (c Config) Redacted() Config {
+	tokens := make([]TokenConfig, len(c.Auth.Tokens))
+
+	for i, token := range c.Auth.Tokens {
+		token.Token = redactedConfigValue
+		tokens[i] = token
+	}
+
+	hooks := make([]HookConfig, len(c.Hooks))
+
+	for i, hook := range c.Hooks {
+		if len(hook.Secret) > 0 {
+			hook.Secret = redactedConfigValue
+		}
+
+		hooks[i] = hook
+	}
+
+	c.Auth.Tokens = tokens
+	c.Hooks = hooks
+	c.Listen = append([]string(nil), c.Listen...)
+	return c
+}
+
+func (c Config) YAML() (string, error) {
+	configBytes, err := yaml.Marshal(c)
+	return string(configBytes), err
+}
+
+// ParseListenAddress parses unix:///path, tcp://host:port and bare host:port addresses
+func ParseListenAddress(address string) (network string, listenAddress string, err error) {
+	if socketFilepath, ok := strings.CutPrefix(address, "unix://"); ok {
+		return "unix", socketFilepath, nil
+	}
+
+	if tcpAddress, ok := strings.CutPrefix(address, "tcp://"); ok {
+		address = tcpAddress
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return "", "", fmt.Errorf("listen address %q: %w", address, err)
+	}
+
+	return "tcp", address, nil
+}
+
+func Listen(address string) (net.Listener, error) {
+	network, listenAddress, err := ParseListenAddress(address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if network == "unix" {
+		return ListenUnix(listenAddress)
+	}
+
+	return net.Listen(network, listenAddress)
+}
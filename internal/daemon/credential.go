@@ -0,0 +1,204 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"slices"
+	"strconv"
+	"syscall"
+)
+
+func lookupUser(spec string) (*user.User, error) {
+	if _, err := strconv.ParseUint(spec, 10, 32); err == nil {
+		return user.LookupId(spec)
+	}
+
+	return user.Lookup(spec)
+}
+
+// lookupUnitUser returns the user the unit runs as, the daemon user if the
+// unit has none
+func lookupUnitUser(spec string) (*user.User, error) {
+	if len(spec) == 0 {
+		return user.Current()
+	}
+
+	return lookupUser(spec)
+}
+
+func lookupGroupID(spec string) (uint32, error) {
+	if gid, err := strconv.ParseUint(spec, 10, 32); err == nil {
+		return uint32(gid), nil
+	}
+
+	group, err := user.LookupGroup(spec)
+
+	if err != nil {
+		return 0, err
+	}
+
+	gid, err := strconv.ParseUint(group.Gid, 10, 32)
+	return uint32(gid), err
+}
+
+func parseIDs(ids []string) ([]uint32, error) {
+	parsed := make([]uint32, len(ids))
+
+	for i, id := range ids {
+		n, err := strconv.ParseUint(id, 10, 32)
+
+		if err != nil {
+			return nil, err
+		}
+
+		parsed[i] = uint32(n)
+	}
+
+	return parsed, nil
+}
+
+// resolveUnitCredential returns nil when the unit should run with the daemon's own credentials
+func resolveUnitCredential(model *UnitModel) (*syscall.Credential, error) {
+	if len(model.User) == 0 && len(model.Group) == 0 && len(model.Groups) == 0 {
+		return nil, nil
+	}
+
+	credential := &syscall.Credential{
+		Uid: uint32(os.Getuid()),
+		Gid: uint32(os.Getgid()),
+	}
+
+	if len(model.User) > 0 {
+		unitUser, err := lookupUser(model.User)
+
+		if err != nil {
+			return nil, fmt.Errorf("lookup user %s: %w", model.User, err)
+		}
+
+		ids, err := parseIDs([]string{unitUser.Uid, unitUser.Gid})
+
+		if err != nil {
+			return nil, err
+		}
+
+		credential.Uid = ids[0]
+		credential.Gid = ids[1]
+
+		if len(model.Groups) == 0 {
+			groupIDs, err := unitUser.GroupIds()
+
+			if err != nil {
+				return nil, fmt.Errorf("lookup groups of %s: %w", model.User, err)
+			}
+
+			if credential.Groups, err = parseIDs(groupIDs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(model.Group) > 0 {
+		gid, err := lookupGroupID(model.Group)
+
+		if err != nil {
+			return nil, fmt.Errorf("lookup group %s: %w", model.Group, err)
+		}
+
+		credential.Gid = gid
+	}
+
+	if len(model.Groups) > 0 {
+		credential.Groups = make([]uint32, len(model.Groups))
+
+		for i, group := range model.Groups {
+			gid, err := lookupGroupID(group)
+
+			if err != nil {
+				return nil, fmt.Errorf("lookup group %s: %w", group, err)
+			}
+
+			credential.Groups[i] = gid
+		}
+	}
+
+	if credential.Uid == uint32(os.Getuid()) &&
+		credential.Gid == uint32(os.Getgid()) &&
+		len(model.Groups) == 0 {
+		return nil, nil
+	}
+
+	return credential, nil
+}
+
+func callerGroupIDs(caller *Caller) ([]uint32, error) {
+	groupIDs := []uint32{caller.GID}
+	callerUser, err := user.LookupId(strconv.FormatUint(uint64(caller.UID), 10))
+
+	if err != nil {
+		return groupIDs, nil
+	}
+
+	supplementaryIDs, err := callerUser.GroupIds()
+
+	if err != nil {
+		return groupIDs, nil
+	}
+
+	parsed, err := parseIDs(supplementaryIDs)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(groupIDs, parsed...), nil
+}
+
+// authorizeUnitCredential defaults the unit user to the caller and makes sure
+// that non-root callers can only run units as themselves and their own groups
+func authorizeUnitCredential(caller *Caller, model *UnitModel) error {
+	if caller == nil {
+		return errors.New("the caller could not be identified")
+	}
+
+	if caller.Network {
+		return nil
+	}
+
+	if len(model.User) == 0 {
+		model.User = strconv.FormatUint(uint64(caller.UID), 10)
+	}
+
+	if caller.UID == 0 {
+		return nil
+	}
+
+	credential, err := resolveUnitCredential(model)
+
+	if err != nil {
+		return err
+	}
+
+	if credential == nil {
+		credential = &syscall.Credential{Uid: uint32(os.Getuid()), Gid: uint32(os.Getgid())}
+	}
+
+	if credential.Uid != caller.UID {
+		return fmt.Errorf("uid %d is not allowed to run units as uid %d", caller.UID, credential.Uid)
+	}
+
+	allowedGroupIDs, err := callerGroupIDs(caller)
+
+	if err != nil {
+		return err
+	}
+
+	for _, gid := range append([]uint32{credential.Gid}, credential.Groups...) {
+		if !slices.Contains(allowedGroupIDs, gid) {
+			return fmt.Errorf("uid %d is not allowed to run units with gid %d", caller.UID, gid)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package daemon
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+// DashboardHandler serves the web dashboard with the HTTP API it's built on.
+// The static assets are embedded and public
+func (s *DaemonServer) DashboardHandler(authenticator *Authenticator) http.Handler {
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+
+	if err != nil {
+		panic(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /", http.FileServerFS(assets))
+	s.handleGatewayRoutes(mux, authenticator)
+	return mux
+}
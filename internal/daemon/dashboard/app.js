@@ -0,0 +1,492 @@
+"use strict";
+
+const statusNames = ["running", "exited", "failed", "stopped"];
+const maxLogLines = 5000;
+const reconnectDelay = 2000;
+const tokenKey = "pm0.token";
+const logLines = 200;
+
+const elements = {
+  connection: document.getElementById("connection"),
+  logout: document.getElementById("logout"),
+  login: document.getElementById("login"),
+  token: document.getElementById("token"),
+  error: document.getElementById("error"),
+  units: document.querySelector("#units tbody"),
+  empty: document.getElementById("empty"),
+  logsPanel: document.getElementById("logs-panel"),
+  logsTitle: document.getElementById("logs-title"),
+  logsScroll: document.getElementById("logs-scroll"),
+  logsClose: document.getElementById("logs-close"),
+  logs: document.getElementById("logs"),
+  envPanel: document.getElementById("env-panel"),
+  envTitle: document.getElementById("env-title"),
+  envClose: document.getElementById("env-close"),
+  envForm: document.getElementById("env-form"),
+  env: document.getElementById("env"),
+  envRestart: document.getElementById("env-restart"),
+  envResult: document.getElementById("env-result"),
+};
+
+let token = localStorage.getItem(tokenKey) || "";
+let units = [];
+let eventsAbort = null;
+let logsAbort = null;
+let envUnit = null;
+let envOriginal = new Map();
+let refreshTimer = null;
+let uptimeCells = [];
+
+class APIError extends Error {
+  constructor(status, body) {
+    super(body.error || `HTTP ${status}`);
+    this.status = status;
+    this.code = body.code;
+  }
+}
+
+function headers() {
+  const result = {};
+
+  if (token) {
+    result.Authorization = `Bearer ${token}`;
+  }
+
+  return result;
+}
+
+async function checkResponse(response) {
+  if (response.ok) {
+    return response;
+  }
+
+  const body = await response.json().catch(() => ({}));
+  const error = new APIError(response.status, body);
+
+  if (response.status === 401) {
+    showLogin();
+  }
+
+  throw error;
+}
+
+async function api(method, path, body) {
+  const init = { method, headers: headers() };
+
+  // the daemon refuses changes without the JSON content type, a form of
+  // another site can't send them
+  if (method !== "GET") {
+    init.headers["Content-Type"] = "application/json";
+  }
+
+  if (body !== undefined) {
+    init.body = JSON.stringify(body);
+  }
+
+  const response = await fetch(path, init).then(checkResponse);
+  return response.json();
+}
+
+// stream reads the server-sent events of path until it ends or is aborted.
+// fetch is used instead of EventSource, which can't send the token header
+async function stream(path, signal, onData) {
+  const init = { headers: { ...headers(), Accept: "text/event-stream" }, signal };
+  const response = await fetch(path, init).then(checkResponse);
+  const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+  let buffer = "";
+
+  for (;;) {
+    const { value, done } = await reader.read();
+
+    if (done) {
+      return;
+    }
+
+    buffer += value;
+    let end;
+
+    while ((end = buffer.indexOf("\n\n")) !== -1) {
+      const message = buffer.slice(0, end);
+      buffer = buffer.slice(end + 2);
+
+      let event = "message";
+      let data = "";
+
+      for (const line of message.split("\n")) {
+        if (line.startsWith("event: ")) {
+          event = line.slice(7);
+        } else if (line.startsWith("data: ")) {
+          data += line.slice(6);
+        }
+      }
+
+      if (data.length === 0) {
+        continue;
+      }
+
+      const parsed = JSON.parse(data);
+
+      if (event === "error") {
+        throw new APIError(500, parsed);
+      }
+
+      onData(parsed);
+    }
+  }
+}
+
+function showError(error) {
+  elements.error.textContent = error ? error.message : "";
+  elements.error.hidden = !error;
+}
+
+function showLogin() {
+  elements.login.hidden = false;
+  elements.connection.textContent = "signed out";
+  elements.connection.classList.remove("live");
+}
+
+function formatDuration(seconds) {
+  const days = Math.floor(seconds / 86400);
+  const hours = Math.floor((seconds % 86400) / 3600);
+  const minutes = Math.floor((seconds % 3600) / 60);
+
+  if (days > 0) {
+    return `${days}d ${hours}h`;
+  }
+
+  if (hours > 0) {
+    return `${hours}h ${minutes}m`;
+  }
+
+  if (minutes > 0) {
+    return `${minutes}m ${seconds % 60}s`;
+  }
+
+  return `${seconds}s`;
+}
+
+function cell(row, text, className) {
+  const td = row.insertCell();
+  td.textContent = text;
+
+  if (className) {
+    td.className = className;
+  }
+
+  return td;
+}
+
+function button(parent, text, onClick, className) {
+  const element = document.createElement("button");
+  element.type = "button";
+  element.textContent = text;
+  element.addEventListener("click", onClick);
+
+  if (className) {
+    element.className = className;
+  }
+
+  parent.append(element, " ");
+}
+
+function uptime(unit) {
+  const seconds = Math.floor(Date.now() / 1000 - Number(unit.started_at));
+  return formatDuration(Math.max(0, seconds));
+}
+
+function render() {
+  uptimeCells = [];
+  elements.units.replaceChildren();
+  elements.empty.hidden = units.length > 0;
+
+  for (const unit of units) {
+    const status = statusNames[unit.status] || "unknown";
+    const running = status === "running";
+    const row = elements.units.insertRow();
+
+    cell(row, unit.id);
+    cell(row, unit.name);
+    cell(row, status, `status-${status}`);
+    cell(row, unit.health);
+    cell(row, running ? unit.pid : "");
+
+    const uptimeCell = cell(row, running ? uptime(unit) : "");
+
+    if (running) {
+      uptimeCells.push({ cell: uptimeCell, unit });
+    }
+
+    cell(row, unit.restarts_count);
+
+    const labels = cell(row, "");
+
+    for (const [key, value] of Object.entries(unit.labels || {})) {
+      const label = document.createElement("span");
+      label.className = "label";
+      label.textContent = `${key}=${value}`;
+      labels.append(label);
+    }
+
+    const actions = cell(row, "", "actions");
+    button(actions, "Logs", () => openLogs(unit));
+    button(actions, "Env", () => openEnv(unit));
+    button(actions, running ? "Restart" : "Start", () => unitAction(unit, "restart"));
+
+    if (running) {
+      button(actions, "Stop", () => unitAction(unit, "stop"));
+    }
+
+    button(actions, "Delete", () => deleteUnit(unit), "danger");
+  }
+}
+
+async function refreshUnits() {
+  try {
+    const response = await api("GET", "/v1/units");
+    units = response.units;
+    elements.login.hidden = true;
+    elements.logout.hidden = !token;
+    showError(null);
+    render();
+  } catch (error) {
+    showError(error);
+  }
+}
+
+// scheduleRefresh coalesces the list refreshes of event bursts
+function scheduleRefresh() {
+  if (refreshTimer === null) {
+    refreshTimer = setTimeout(() => {
+      refreshTimer = null;
+      refreshUnits();
+    }, 200);
+  }
+}
+
+async function watchEvents() {
+  if (eventsAbort) {
+    eventsAbort.abort();
+  }
+
+  const abort = new AbortController();
+  eventsAbort = abort;
+
+  while (!abort.signal.aborted) {
+    await refreshUnits();
+
+    try {
+      elements.connection.textContent = "live";
+      elements.connection.classList.add("live");
+      await stream("/v1/events", abort.signal, (event) => {
+        if (event.unit) {
+          scheduleRefresh();
+        }
+      });
+    } catch (error) {
+      if (abort.signal.aborted || (error instanceof APIError && error.status === 401)) {
+        return;
+      }
+    }
+
+    elements.connection.textContent = "reconnecting";
+    elements.connection.classList.remove("live");
+    await new Promise((resolve) => setTimeout(resolve, reconnectDelay));
+  }
+}
+
+async function unitAction(unit, action) {
+  try {
+    await api("POST", `/v1/units/${unit.id}/${action}`);
+    showError(null);
+  } catch (error) {
+    showError(error);
+  }
+
+  scheduleRefresh();
+}
+
+async function deleteUnit(unit) {
+  if (!confirm(`Delete unit ${unit.name} (${unit.id})?`)) {
+    return;
+  }
+
+  try {
+    await api("DELETE", `/v1/units/${unit.id}`);
+    showError(null);
+  } catch (error) {
+    showError(error);
+  }
+
+  scheduleRefresh();
+}
+
+function appendLogLine(line) {
+  const follow = elements.logsScroll.checked;
+  elements.logs.append(line + "\n");
+
+  while (elements.logs.childNodes.length > maxLogLines) {
+    elements.logs.firstChild.remove();
+  }
+
+  if (follow) {
+    elements.logs.scrollTop = elements.logs.scrollHeight;
+  }
+}
+
+function closeLogs() {
+  if (logsAbort) {
+    logsAbort.abort();
+    logsAbort = null;
+  }
+
+  elements.logsPanel.hidden = true;
+}
+
+async function openLogs(unit) {
+  closeLogs();
+
+  const abort = new AbortController();
+  logsAbort = abort;
+  elements.logsTitle.textContent = `Logs of ${unit.name} (${unit.id})`;
+  elements.logs.replaceChildren();
+  elements.logsPanel.hidden = false;
+
+  try {
+    await stream(`/v1/units/${unit.id}/logs?follow=true&lines=${logLines}`, abort.signal, (response) => {
+      if (!response.flush) {
+        appendLogLine(response.line);
+      }
+    });
+  } catch (error) {
+    if (!abort.signal.aborted) {
+      appendLogLine(`-- ${error.message}`);
+    }
+  }
+}
+
+function parseEnv(text) {
+  const env = new Map();
+
+  for (const line of text.split("\n")) {
+    const trimmed = line.trim();
+
+    if (trimmed.length === 0 || trimmed.startsWith("#")) {
+      continue;
+    }
+
+    const separator = trimmed.indexOf("=");
+
+    if (separator === -1) {
+      env.set(trimmed, "");
+    } else {
+      env.set(trimmed.slice(0, separator), trimmed.slice(separator + 1));
+    }
+  }
+
+  return env;
+}
+
+function closeEnv() {
+  envUnit = null;
+  elements.envPanel.hidden = true;
+}
+
+async function openEnv(unit) {
+  try {
+    const response = await api("GET", `/v1/units/${unit.id}`);
+    envUnit = unit;
+    envOriginal = parseEnv(response.env.join("\n"));
+    elements.envTitle.textContent = `Env of ${unit.name} (${unit.id})`;
+    elements.env.value = response.env.join("\n");
+    elements.envResult.textContent = "";
+    elements.envPanel.hidden = false;
+    showError(null);
+  } catch (error) {
+    showError(error);
+  }
+}
+
+async function saveEnv(event) {
+  event.preventDefault();
+
+  if (!envUnit) {
+    return;
+  }
+
+  const edited = parseEnv(elements.env.value);
+  const changes = [];
+
+  for (const [key, value] of edited) {
+    if (envOriginal.get(key) !== value) {
+      changes.push(`${key}=${value}`);
+    }
+  }
+
+  for (const key of envOriginal.keys()) {
+    if (!edited.has(key)) {
+      changes.push(`${key}=`);
+    }
+  }
+
+  if (changes.length === 0) {
+    elements.envResult.textContent = "nothing changed";
+    return;
+  }
+
+  try {
+    // merged env keeps the masked values the dashboard never saw
+    const response = await api("PATCH", `/v1/units/${envUnit.id}`, {
+      env: changes,
+      update_mask: "env",
+      merge_env: true,
+      restart: elements.envRestart.checked,
+    });
+
+    elements.envResult.textContent = response.restart_error
+      ? `saved, restart failed: ${response.restart_error}`
+      : "saved";
+
+    envOriginal = edited;
+    showError(null);
+  } catch (error) {
+    showError(error);
+  }
+
+  scheduleRefresh();
+}
+
+elements.login.addEventListener("submit", (event) => {
+  event.preventDefault();
+  token = elements.token.value.trim();
+  localStorage.setItem(tokenKey, token);
+  elements.token.value = "";
+  watchEvents();
+});
+
+elements.logout.addEventListener("click", () => {
+  token = "";
+  localStorage.removeItem(tokenKey);
+  closeLogs();
+  closeEnv();
+  units = [];
+  render();
+
+  if (eventsAbort) {
+    eventsAbort.abort();
+  }
+
+  showLogin();
+});
+
+elements.logsClose.addEventListener("click", closeLogs);
+elements.envClose.addEventListener("click", closeEnv);
+elements.envForm.addEventListener("submit", saveEnv);
+
+// the uptimes tick between the events, the rows are kept so clicks aren't lost
+setInterval(() => {
+  for (const { cell, unit } of uptimeCells) {
+    cell.textContent = uptime(unit);
+  }
+}, 1000);
+watchEvents();
@@ -0,0 +1,345 @@
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const (
+	EnvModeNone   = "none"
+	EnvModeDaemon = "daemon"
+	EnvModeAllow  = "allow"
+
+	envFileReferencePrefix = "@file:"
+	maskedEnvValue         = "********"
+)
+
+// secretEnvKeyParts are the parts of env var names whose values are masked in show
+var secretEnvKeyParts = []string{
+	"SECRET",
+	"TOKEN",
+	"PASS",
+	"KEY",
+	"CREDENTIAL",
+	"PRIVATE",
+	"AUTH",
+	"DSN",
+}
+
+func ParseEnvMode(mode string) (string, error) {
+	switch mode {
+	case EnvModeNone, EnvModeDaemon, EnvModeAllow:
+		return mode, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown env mode %q, expected %s, %s or %s",
+			mode,
+			EnvModeNone,
+			EnvModeDaemon,
+			EnvModeAllow,
+		)
+	}
+}
+
+// unitEnvMode returns the env inheritance mode of the unit and the allowed
+// daemon env var names. An allowlist without a mode means the allow mode
+func (s *DaemonServer) unitEnvMode(model *UnitModel) (string, []string) {
+	defaults := s.getConfig().Units
+	mode := model.EnvMode
+	allow := model.EnvAllow
+
+	if len(mode) == 0 && len(allow) > 0 {
+		mode = EnvModeAllow
+	}
+
+	if len(mode) == 0 {
+		mode = defaults.EnvMode
+	}
+
+	if len(allow) == 0 {
+		allow = defaults.EnvAllow
+	}
+
+	return mode, allow
+}
+
+// resolveUnitEnv builds the env of a unit process: the inherited daemon env,
+// then the env files, then the unit env. Env files and @file: references
+// are read on every start with the unit credential, @secret: references are
+// decrypted from the secrets of the unit owner
+func (s *DaemonServer) resolveUnitEnv(model *UnitModel) ([]string, error) {
+	credential, err := resolveUnitCredential(model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mode, allow := s.unitEnvMode(model)
+	env := inheritedEnv(mode, allow)
+
+	if mode != EnvModeNone {
+		if unitUser, err := lookupUnitUser(model.User); err == nil {
+			env = mergeEnv(env, []string{
+				"HOME=" + unitUser.HomeDir,
+				"USER=" + unitUser.Username,
+				"LOGNAME=" + unitUser.Username,
+			})
+		}
+	}
+
+	for _, envFile := range model.EnvFiles {
+		if !path.IsAbs(envFile) {
+			envFile = path.Join(model.CWD, envFile)
+		}
+
+		envFileBytes, err := readFileAs(envFile, credential)
+
+		if err != nil {
+			return nil, fmt.Errorf("read env file %s: %w", envFile, err)
+		}
+
+		fileEnv, err := parseEnvFile(envFileBytes)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", envFile, err)
+		}
+
+		env = mergeEnv(env, fileEnv)
+	}
+
+	env = mergeEnv(env, model.Env)
+
+	for i, e := range env {
+		k, v, _ := strings.Cut(e, "=")
+
+		if secretName, ok := strings.CutPrefix(v, secretReferencePrefix); ok {
+			value, err := s.getSecretValue(model.OwnerUID, secretName)
+
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", k, err)
+			}
+
+			env[i] = k + "=" + string(value)
+			continue
+		}
+
+		filepath, ok := strings.CutPrefix(v, envFileReferencePrefix)
+
+		if !ok {
+			continue
+		}
+
+		if !path.IsAbs(filepath) {
+			filepath = path.Join(model.CWD, filepath)
+		}
+
+		value, err := readFileAs(filepath, credential)
+
+		if err != nil {
+			return nil, fmt.Errorf("read %s of %s: %w", filepath, k, err)
+		}
+
+		env[i] = k + "=" + strings.TrimRight(string(value), "\r\n")
+	}
+
+	return env, nil
+}
+
+// mergeEnv overrides the env vars of env by the vars of override, keeping the order
+func mergeEnv(env []string, override []string) []string {
+	merged := make([]string, 0, len(env)+len(override))
+	indices := make(map[string]int, len(env)+len(override))
+
+	for _, e := range slices.Concat(env, override) {
+		k, _, _ := strings.Cut(e, "=")
+
+		if i, ok := indices[k]; ok {
+			merged[i] = e
+			continue
+		}
+
+		indices[k] = len(merged)
+		merged = append(merged, e)
+	}
+
+	return merged
+}
+
+func inheritedEnv(mode string, allow []string) []string {
+	switch mode {
+	case EnvModeDaemon:
+		return os.Environ()
+	case EnvModeAllow:
+		var env []string
+
+		for _, e := range os.Environ() {
+			k, _, _ := strings.Cut(e, "=")
+
+			for _, pattern := range allow {
+				if matched, err := path.Match(pattern, k); err == nil && matched {
+					env = append(env, e)
+					break
+				}
+			}
+		}
+
+		return env
+	default:
+		return nil
+	}
+}
+
+// readFileAs reads the file with the unit credential, so units can't be
+// used to read the files only the daemon user has access to
+func readFileAs(filepath string, credential *syscall.Credential) ([]byte, error) {
+	if credential == nil {
+		return os.ReadFile(filepath)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	command := exec.Command("/bin/cat", "--", filepath)
+	command.Stdout = &stdout
+	command.Stderr = &stderr
+	command.SysProcAttr = &syscall.SysProcAttr{Credential: credential}
+
+	if err := command.Run(); err != nil {
+		return nil, commandOutputError(err, stderr.Bytes())
+	}
+
+	return stdout.Bytes(), nil
+}
+
+// parseEnvFile parses dotenv files: KEY=VALUE lines with an optional export
+// prefix, # comments, and single or double quoted values. Double quoted values
+// can span lines and have \n, \t, \" and \\ escapes
+func parseEnvFile(content []byte) ([]string, error) {
+	var env []string
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	lineNumber := 0
+
+	for scanner.Scan() {
+		lineNumber += 1
+		line := strings.TrimSpace(scanner.Text())
+
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		k, v, ok := strings.Cut(line, "=")
+		k = strings.TrimSpace(k)
+
+		if !ok || len(k) == 0 || strings.ContainsAny(k, " \t") {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+
+		v = strings.TrimSpace(v)
+
+		switch {
+		case strings.HasPrefix(v, `"`):
+			// the line breaks are escaped, Unquote doesn't accept them
+			for closingDoubleQuote(v) < 0 && scanner.Scan() {
+				lineNumber += 1
+				v += `\n` + scanner.Text()
+			}
+
+			end := closingDoubleQuote(v)
+
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value of %s", lineNumber, k)
+			}
+
+			if !isEnvLineEnd(v[end+1:]) {
+				return nil, fmt.Errorf("line %d: unexpected text after the quoted value of %s", lineNumber, k)
+			}
+
+			unquoted, err := strconv.Unquote(v[:end+1])
+
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid quoted value of %s", lineNumber, k)
+			}
+
+			v = unquoted
+		case strings.HasPrefix(v, "'"):
+			end := strings.Index(v[1:], "'")
+
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated quoted value of %s", lineNumber, k)
+			}
+
+			if !isEnvLineEnd(v[end+2:]) {
+				return nil, fmt.Errorf("line %d: unexpected text after the quoted value of %s", lineNumber, k)
+			}
+
+			v = v[1 : end+1]
+		default:
+			if comment := strings.Index(v, " #"); comment >= 0 {
+				v = strings.TrimSpace(v[:comment])
+			}
+		}
+
+		env = append(env, k+"="+v)
+	}
+
+	return env, scanner.Err()
+}
+
+// isEnvLineEnd reports whether the rest of the line after a quoted value is
+// blank or a comment
+func isEnvLineEnd(rest string) bool {
+	trimmed := strings.TrimLeft(rest, " \t")
+	return len(strings.TrimSpace(trimmed)) == 0 || (trimmed[0] == '#' && len(trimmed) < len(rest))
+}
+
+// closingDoubleQuote returns the index of the unescaped quote closing the
+// value or -1 if it's not closed
+func closingDoubleQuote(v string) int {
+	for i := 1; i < len(v); i++ {
+		switch v[i] {
+		case '\\':
+			i += 1
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+// MaskEnv hides the values of the env vars that look like secrets.
+// @file: and @secret: references are kept, they don't contain the secret itself
+func MaskEnv(env []string) []string {
+	masked := make([]string, len(env))
+
+	for i, e := range env {
+		k, v, _ := strings.Cut(e, "=")
+		masked[i] = e
+
+		if len(v) == 0 ||
+			strings.HasPrefix(v, envFileReferencePrefix) ||
+			strings.HasPrefix(v, secretReferencePrefix) {
+			continue
+		}
+
+		upperKey := strings.ToUpper(k)
+
+		for _, part := range secretEnvKeyParts {
+			if strings.Contains(upperKey, part) {
+				masked[i] = k + "=" + maskedEnvValue
+				break
+			}
+		}
+	}
+
+	return masked
+}
@@ -0,0 +1,65 @@
+package daemon
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseEnvFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		env     []string
+		err     string
+	}{
+		{"plain", "A=1\nB=two words", []string{"A=1", "B=two words"}, ""},
+		{"empty file", "", nil, ""},
+		{"empty value", "KEY=", []string{"KEY="}, ""},
+		{"spaces around", "  KEY = value  ", []string{"KEY=value"}, ""},
+		{"value with =", "URL=a=b", []string{"URL=a=b"}, ""},
+		{"export", "export KEY=value", []string{"KEY=value"}, ""},
+		{"comments and blank lines", "# comment\n\n  # indented\nA=1", []string{"A=1"}, ""},
+		{"inline comment", "A=1 # comment", []string{"A=1"}, ""},
+		{"# without a space", "A=a#b", []string{"A=a#b"}, ""},
+		{"# in double quotes", `A="a # b"`, []string{"A=a # b"}, ""},
+		{"# in single quotes", "A='a # b'", []string{"A=a # b"}, ""},
+		{"comment after quotes", `A="a" # b`, []string{"A=a"}, ""},
+		{"double quote escapes", `A="a\tb\n\"c\"\\"`, []string{"A=a\tb\n\"c\"\\"}, ""},
+		{"single quotes are raw", `A='a\nb "c"'`, []string{`A=a\nb "c"`}, ""},
+		{"empty quotes", `A=""` + "\nB=''", []string{"A=", "B="}, ""},
+		{"multiline double quotes", "A=\"first\nsecond\"\nB=1", []string{"A=first\nsecond", "B=1"}, ""},
+		{"unterminated double quotes", "A=\"value\nB=1", nil, "line 2: unterminated quoted value of A"},
+		{"unterminated single quotes", "A='value\nB=1", nil, "line 1: unterminated quoted value of A"},
+		{"invalid escape", `A="\q"`, nil, "line 1: invalid quoted value of A"},
+		{"text after double quotes", `A="a"junk`, nil, "line 1: unexpected text after the quoted value of A"},
+		{"text after single quotes", "A='a' junk", nil, "line 1: unexpected text after the quoted value of A"},
+		{"# right after quotes", `A="a"#b`, nil, "line 1: unexpected text after the quoted value of A"},
+		{"spaces after quotes", "A=\"a\"  \nB='b'\t", []string{"A=a", "B=b"}, ""},
+		{"no =", "A=1\nKEY", nil, "line 2: expected KEY=VALUE"},
+		{"empty key", "=value", nil, "line 1: expected KEY=VALUE"},
+		{"key with a space", "MY KEY=value", nil, "line 1: expected KEY=VALUE"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			env, err := parseEnvFile([]byte(test.content))
+
+			if len(test.err) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("err %v, expected %q", err, test.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !slices.Equal(env, test.env) {
+				t.Fatalf("env %q, expected %q", env, test.env)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package daemon
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	EventDeleted         = "deleted"
+	EventUpdated         = "updated"
+	EventDaemonStarted   = "daemon_started"
+	EventDaemonReloaded  = "daemon_reloaded"
+	EventDaemonStopping  = "daemon_stopping"
+	eventReplaySize      = 1024
+	eventSubscriberQueue = 256
+)
+
+var errEventSubscriberOverflow = errors.New("event subscriber is too slow")
+
+type eventSubscriber struct {
+	events chan *pb.Event
+	err    error
+}
+
+// EventBus fans out daemon events to the subscribers. The last events are
+// kept, so a reconnecting subscriber can resume from the last seen sequence number
+type EventBus struct {
+	mu          sync.Mutex
+	seq         uint64
+	replay      []*pb.Event
+	subscribers map[*eventSubscriber]struct{}
+	closed      bool
+}
+
+func NewEventBus() *EventBus {
+	return &EventBus{
+		replay:      make([]*pb.Event, 0, eventReplaySize),
+		subscribers: make(map[*eventSubscriber]struct{}),
+	}
+}
+
+func (b *EventBus) Publish(event *pb.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.seq += 1
+	event.Seq = b.seq
+	event.Time = time.Now().UnixMilli()
+
+	if len(b.replay) == eventReplaySize {
+		copy(b.replay, b.replay[1:])
+		b.replay = b.replay[:eventReplaySize-1]
+	}
+
+	b.replay = append(b.replay, event)
+
+	for subscriber := range b.subscribers {
+		select {
+		case subscriber.events <- event:
+		default:
+			subscriber.err = errEventSubscriberOverflow
+			b.unsubscribe(subscriber)
+		}
+	}
+}
+
+// Subscribe returns the buffered events after the since sequence number and
+// the subscriber for the new ones. since of 0 means no replay
+func (b *EventBus) Subscribe(since uint64) ([]*pb.Event, *eventSubscriber, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if since > b.seq {
+		return nil, nil, status.Errorf(
+			codes.OutOfRange,
+			"sequence number %d is ahead of the daemon (%d), it was restarted",
+			since,
+			b.seq,
+		)
+	}
+
+	var replay []*pb.Event
+
+	if since > 0 && since < b.seq {
+		oldestSeq := b.seq - uint64(len(b.replay)) + 1
+
+		if since+1 < oldestSeq {
+			return nil, nil, status.Errorf(
+				codes.OutOfRange,
+				"events after %d are no longer buffered, the oldest is %d",
+				since,
+				oldestSeq,
+			)
+		}
+
+		replay = append(replay, b.replay[since+1-oldestSeq:]...)
+	}
+
+	subscriber := &eventSubscriber{events: make(chan *pb.Event, eventSubscriberQueue)}
+
+	if b.closed {
+		close(subscriber.events)
+	} else {
+		b.subscribers[subscriber] = struct{}{}
+	}
+
+	return replay, subscriber, nil
+}
+
+func (b *EventBus) Unsubscribe(subscriber *eventSubscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.subscribers[subscriber]; ok {
+		b.unsubscribe(subscriber)
+	}
+}
+
+func (b *EventBus) unsubscribe(subscriber *eventSubscriber) {
+	delete(b.subscribers, subscriber)
+	close(subscriber.events)
+}
+
+// Close ends the subscriptions, so the event streams don't hold the daemon shutdown
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+
+	for subscriber := range b.subscribers {
+		b.unsubscribe(subscriber)
+	}
+}
+
+func (s *DaemonServer) publishUnitEvent(eventType string, unit *Unit, unitEvent *UnitEventModel) {
+	event := &pb.Event{
+		Type: eventType,
+		Unit: unit.PB(),
+	}
+
+	if unitEvent != nil {
+		event.UnitEvent = unitEvent.PB()
+	}
+
+	s.events.Publish(event)
+}
+
+func (s *DaemonServer) publishDaemonEvent(eventType string, message string) {
+	s.events.Publish(&pb.Event{Type: eventType, Message: message})
+}
+
+// BeginShutdown tells the event subscribers that the daemon is stopping and
+// ends their streams
+func (s *DaemonServer) BeginShutdown(reason string) {
+	s.publishDaemonEvent(EventDaemonStopping, reason)
+	s.events.Close()
+}
+
+// eventVisible reports whether the caller may see the event and it matches
+// the selectors. Daemon events are sent to every subscriber
+func eventVisible(caller *Caller, event *pb.Event, selectors []string) bool {
+	if event.Unit == nil {
+		return true
+	}
+
+	if !caller.IsAdmin() && (caller == nil || caller.UID != event.Unit.OwnerUid) {
+		return false
+	}
+
+	return UnitMatchesSelectors(event.Unit, selectors)
+}
+
+func (s *DaemonServer) Events(request *pb.EventsRequest, stream pb.ProcessService_EventsServer) error {
+	caller := callerFromContext(stream.Context())
+	replay, subscriber, err := s.events.Subscribe(request.Since)
+
+	if err != nil {
+		return err
+	}
+
+	defer s.events.Unsubscribe(subscriber)
+
+	lastSeq := request.Since
+
+	for _, event := range replay {
+		lastSeq = event.Seq
+
+		if !eventVisible(caller, event, request.Selectors) {
+			continue
+		}
+
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event, ok := <-subscriber.events:
+			if !ok {
+				if subscriber.err != nil {
+					return status.Errorf(
+						codes.ResourceExhausted,
+						"%s, resume from %d",
+						subscriber.err,
+						lastSeq,
+					)
+				}
+
+				return nil
+			}
+
+			lastSeq = event.Seq
+
+			if !eventVisible(caller, event, request.Selectors) {
+				continue
+			}
+
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package daemon
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// execWaitDelay limits the wait for the output pipes after the exec command
+// exits, its children may still hold them open
+const execWaitDelay = time.Second
+
+// execSender serializes the responses of the stdout and stderr copies
+type execSender struct {
+	mu     sync.Mutex
+	stream pb.ProcessService_ExecServer
+}
+
+func (s *execSender) send(response *pb.ExecResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.stream.Send(response)
+}
+
+// execOutput writes the output of the exec command as stdout or stderr responses
+type execOutput struct {
+	sender *execSender
+	stderr bool
+}
+
+func (o *execOutput) Write(p []byte) (int, error) {
+	response := &pb.ExecResponse{Stdout: p}
+
+	if o.stderr {
+		response = &pb.ExecResponse{Stderr: p}
+	}
+
+	if err := o.sender.send(response); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// Exec runs a one-off command with the cwd, env and credential of the unit
+// and streams its output until it exits. The last response carries the exit
+// code. The command is killed when the client goes away
+func (s *DaemonServer) Exec(stream pb.ProcessService_ExecServer) error {
+	request, err := stream.Recv()
+
+	if err != nil {
+		return err
+	}
+
+	if len(request.Bin) == 0 {
+		return status.Error(codes.InvalidArgument, "specify the command to run")
+	}
+
+	unit := s.getUnit(callerFromContext(stream.Context()), request.UnitId)
+
+	if unit == nil {
+		return status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
+	}
+
+	model := unit.ModelCopy()
+	model.Bin = request.Bin
+	model.Args = request.Args
+
+	credential, err := resolveUnitCredential(&model)
+
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	env, err := s.resolveUnitEnv(&model)
+
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	sender := &execSender{stream: stream}
+	command := createUnitStartCommand(&model, env, &execOutput{sender: sender})
+	command.Stderr = &execOutput{sender: sender, stderr: true}
+	command.SysProcAttr = &syscall.SysProcAttr{Credential: credential, Setpgid: true}
+	command.WaitDelay = execWaitDelay
+
+	var (
+		stdin            io.WriteCloser
+		master, terminal *os.File
+	)
+
+	if request.Tty {
+		if master, terminal, err = openUnitPTY(command); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		defer master.Close()
+		stdin = master
+
+		if request.Rows > 0 && request.Cols > 0 {
+			setPTYSize(master, uint16(request.Rows), uint16(request.Cols))
+		}
+	} else if stdin, err = command.StdinPipe(); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	err = command.Start()
+
+	if terminal != nil {
+		terminal.Close()
+	}
+
+	if err != nil {
+		return status.Errorf(codes.FailedPrecondition, "run %s: %s", request.Bin, err)
+	}
+
+	slog.Info(
+		"exec in unit",
+		"id", model.ID,
+		"pid", command.Process.Pid,
+		"bin", request.Bin,
+		"by", describeCaller(stream.Context()),
+	)
+
+	var ptyOutputDone <-chan struct{}
+
+	if master != nil {
+		ptyOutputDone = copyPTYOutput(master, &execOutput{sender: sender}, false)
+	}
+
+	exited := make(chan struct{})
+
+	go func() {
+		<-stream.Context().Done()
+
+		select {
+		case <-exited:
+		default:
+			syscall.Kill(-command.Process.Pid, syscall.SIGKILL)
+		}
+	}()
+
+	go func() {
+		for {
+			input, err := stream.Recv()
+
+			if err != nil {
+				// the end of the client input is the end of the command stdin,
+				// the terminal of tty commands is closed once they exit
+				if master == nil {
+					stdin.Close()
+				}
+
+				return
+			}
+
+			if master != nil && input.Rows > 0 && input.Cols > 0 {
+				setPTYSize(master, uint16(input.Rows), uint16(input.Cols))
+			}
+
+			if len(input.Data) > 0 {
+				stdin.Write(input.Data)
+			}
+		}
+	}()
+
+	command.Wait()
+
+	if ptyOutputDone != nil {
+		select {
+		case <-ptyOutputDone:
+		case <-time.After(ptyDrainTimeout):
+		}
+	}
+
+	close(exited)
+
+	response := &pb.ExecResponse{Exited: true, ExitCode: int32(command.ProcessState.ExitCode())}
+
+	if waitStatus, ok := command.ProcessState.Sys().(syscall.WaitStatus); ok && waitStatus.Signaled() {
+		response.Signal = SignalName(waitStatus.Signal())
+	}
+
+	return sender.send(response)
+}
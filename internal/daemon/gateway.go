@@ -0,0 +1,327 @@
+package daemon
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// gatewayHandler serves a route with the server it's bound to
+type gatewayHandler func(s *DaemonServer, w http.ResponseWriter, r *http.Request)
+
+type gatewayQueryParam struct {
+	name        string
+	kind        string // boolean, integer or string
+	repeated    bool
+	description string
+}
+
+// gatewayRoute is a route of the HTTP API, its docs are a part of the OpenAPI
+// document. The caller is authenticated as a caller of the grpc method
+type gatewayRoute struct {
+	method      string
+	path        string
+	rpc         string
+	summary     string
+	query       []gatewayQueryParam
+	body        proto.Message // the JSON request body, nil if there is none
+	response    proto.Message
+	unitResults bool // the response has a result per unit like for stop
+	stream      bool // the responses are streamed as NDJSON or server-sent events
+	status      int  // status of the success, 200 if unset
+	handler     gatewayHandler
+}
+
+var gatewayRoutes = []gatewayRoute{
+	{
+		method:   http.MethodGet,
+		path:     "/v1/units",
+		rpc:      pb.ProcessService_List_FullMethodName,
+		summary:  "List the units",
+		response: &pb.ListResponse{},
+		handler:  (*DaemonServer).gatewayList,
+	},
+	{
+		method:   http.MethodPost,
+		path:     "/v1/units",
+		rpc:      pb.ProcessService_Start_FullMethodName,
+		summary:  "Create and start a unit",
+		body:     &pb.StartRequest{},
+		response: &pb.StartResponse{},
+		status:   http.StatusCreated,
+		handler:  (*DaemonServer).gatewayStart,
+	},
+	{
+		method:   http.MethodGet,
+		path:     "/v1/units/{id}",
+		rpc:      pb.ProcessService_Show_FullMethodName,
+		summary:  "Show the unit settings and its latest events",
+		response: &pb.ShowResponse{},
+		handler:  (*DaemonServer).gatewayShow,
+	},
+	{
+		method:   http.MethodPatch,
+		path:     "/v1/units/{id}",
+		rpc:      pb.ProcessService_Update_FullMethodName,
+		summary:  "Update the unit fields listed in update_mask, the unit id is the one of the path",
+		body:     &pb.UpdateRequst{},
+		response: &pb.UpdateResponse{},
+		handler:  (*DaemonServer).gatewayUpdate,
+	},
+	{
+		method:      http.MethodDelete,
+		path:        "/v1/units/{id}",
+		rpc:         pb.ProcessService_Delete_FullMethodName,
+		summary:     "Stop and delete the unit",
+		unitResults: true,
+		handler:     gatewayUnitAction((*DaemonServer).Delete),
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/v1/units/{id}/start",
+		rpc:         pb.ProcessService_StartExisting_FullMethodName,
+		summary:     "Start the stopped unit",
+		unitResults: true,
+		handler:     gatewayUnitAction((*DaemonServer).StartExisting),
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/v1/units/{id}/stop",
+		rpc:         pb.ProcessService_Stop_FullMethodName,
+		summary:     "Stop the unit",
+		unitResults: true,
+		handler:     gatewayUnitAction((*DaemonServer).Stop),
+	},
+	{
+		method:      http.MethodPost,
+		path:        "/v1/units/{id}/restart",
+		rpc:         pb.ProcessService_Restart_FullMethodName,
+		summary:     "Restart the unit, a stopped unit is started",
+		unitResults: true,
+		handler:     gatewayUnitAction((*DaemonServer).Restart),
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/v1/units/{id}/logs",
+		rpc:     pb.ProcessService_Logs_FullMethodName,
+		summary: "Stream the unit log, the last lines are followed by a flush message",
+		query: []gatewayQueryParam{
+			{name: "lines", kind: "integer", description: "number of the last lines to send"},
+			{name: "follow", kind: "boolean", description: "keep sending the new lines"},
+		},
+		response: &pb.LogsResponse{},
+		stream:   true,
+		handler:  (*DaemonServer).gatewayLogs,
+	},
+	{
+		method:  http.MethodGet,
+		path:    "/v1/events",
+		rpc:     pb.ProcessService_Events_FullMethodName,
+		summary: "Stream the unit and daemon events",
+		query: []gatewayQueryParam{
+			{
+				name:        "selector",
+				kind:        "string",
+				repeated:    true,
+				description: "unit selector like in pm0 events, the events of any unit by default",
+			},
+			{
+				name:        "since",
+				kind:        "integer",
+				description: "sequence number of the last seen event to resume after",
+			},
+		},
+		response: &pb.Event{},
+		stream:   true,
+		handler:  (*DaemonServer).gatewayEvents,
+	},
+}
+
+// GatewayHandler serves the HTTP/JSON API of the process service
+func (s *DaemonServer) GatewayHandler(authenticator *Authenticator) http.Handler {
+	mux := http.NewServeMux()
+	s.handleGatewayRoutes(mux, authenticator)
+	return mux
+}
+
+// handleGatewayRoutes registers the routes of the API. They call the RPCs
+// in-process with the same tokens and roles as the grpc listeners, the
+// OpenAPI document is public
+func (s *DaemonServer) handleGatewayRoutes(mux *http.ServeMux, authenticator *Authenticator) {
+	for _, route := range gatewayRoutes {
+		handler := route.handler
+
+		mux.HandleFunc(route.method+" "+route.path, authenticated(
+			authenticator,
+			route.rpc,
+			func(w http.ResponseWriter, r *http.Request) {
+				handler(s, w, r)
+			},
+		))
+	}
+
+	mux.HandleFunc("GET /v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		document, err := OpenAPIDocument()
+
+		if err != nil {
+			writeHTTPError(w, status.Error(codes.Internal, err.Error()))
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	})
+}
+
+func (s *DaemonServer) gatewayList(w http.ResponseWriter, r *http.Request) {
+	response, err := s.List(r.Context(), &emptypb.Empty{})
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	slices.SortFunc(response.Units, func(a *pb.Unit, b *pb.Unit) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	writeProtoJSON(w, http.StatusOK, response)
+}
+
+func (s *DaemonServer) gatewayStart(w http.ResponseWriter, r *http.Request) {
+	request := &pb.StartRequest{}
+
+	if err := decodeProtoJSON(w, r, request); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	response, err := s.Start(r.Context(), request)
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	writeProtoJSON(w, http.StatusCreated, response)
+}
+
+func (s *DaemonServer) gatewayShow(w http.ResponseWriter, r *http.Request) {
+	unitID, err := parseUnitIDPathValue(r)
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	response, err := s.Show(r.Context(), &pb.ShowRequest{UnitId: unitID})
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	writeProtoJSON(w, http.StatusOK, response)
+}
+
+func (s *DaemonServer) gatewayUpdate(w http.ResponseWriter, r *http.Request) {
+	unitID, err := parseUnitIDPathValue(r)
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	request := &pb.UpdateRequst{}
+
+	if err := decodeProtoJSON(w, r, request); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	request.UnitId = unitID
+	response, err := s.Update(r.Context(), request)
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	writeProtoJSON(w, http.StatusOK, response)
+}
+
+// gatewayUnitAction serves the RPCs streaming a result per unit for the unit
+// of the path
+func gatewayUnitAction(
+	action func(s *DaemonServer, request *pb.StopRequest, stream pb.ProcessService_StopServer) error,
+) gatewayHandler {
+	return func(s *DaemonServer, w http.ResponseWriter, r *http.Request) {
+		unitID, err := parseUnitIDPathValue(r)
+
+		if err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+
+		serveUnitsStream(w, r, func(stream pb.ProcessService_StopServer) error {
+			return action(s, &pb.StopRequest{UnitIds: []uint64{unitID}}, stream)
+		})
+	}
+}
+
+func (s *DaemonServer) gatewayLogs(w http.ResponseWriter, r *http.Request) {
+	unitID, err := parseUnitIDPathValue(r)
+
+	if err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	request := &pb.LogsRequest{UnitId: unitID}
+	query := r.URL.Query()
+
+	if query.Has("lines") {
+		if request.Lines, err = strconv.ParseUint(query.Get("lines"), 10, 64); err != nil {
+			writeHTTPError(w, status.Errorf(codes.InvalidArgument, "invalid lines %q", query.Get("lines")))
+			return
+		}
+	}
+
+	if query.Has("follow") {
+		if request.Follow, err = strconv.ParseBool(query.Get("follow")); err != nil {
+			writeHTTPError(w, status.Errorf(codes.InvalidArgument, "invalid follow %q", query.Get("follow")))
+			return
+		}
+	}
+
+	serveStream(w, r, func(stream pb.ProcessService_LogsServer) error {
+		return s.Logs(request, stream)
+	})
+}
+
+func (s *DaemonServer) gatewayEvents(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	request := &pb.EventsRequest{Selectors: query["selector"]}
+
+	if query.Has("since") {
+		since, err := strconv.ParseUint(query.Get("since"), 10, 64)
+
+		if err != nil {
+			writeHTTPError(w, status.Errorf(codes.InvalidArgument, "invalid since %q", query.Get("since")))
+			return
+		}
+
+		request.Since = since
+	}
+
+	serveStream(w, r, func(stream pb.ProcessService_EventsServer) error {
+		return s.Events(request, stream)
+	})
+}
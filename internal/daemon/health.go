@@ -0,0 +1,126 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+type UnitHealth uint32
+
+const (
+	UnitHealthUnknown   UnitHealth = 0
+	UnitHealthHealthy   UnitHealth = 1
+	UnitHealthUnhealthy UnitHealth = 2
+
+	commandOutputLimit = 256
+)
+
+var unitHealthNames = map[UnitHealth]string{
+	UnitHealthUnknown:   "",
+	UnitHealthHealthy:   "healthy",
+	UnitHealthUnhealthy: "unhealthy",
+}
+
+func (health UnitHealth) String() string {
+	return unitHealthNames[health]
+}
+
+func (s *DaemonServer) unitHealthInterval(model *UnitModel) time.Duration {
+	if model.HealthInterval > 0 {
+		return model.HealthInterval
+	}
+
+	return time.Duration(s.getConfig().Units.HealthInterval)
+}
+
+// checkUnitHealth runs the unit health command every health interval until
+// the unit exits. Health transitions are recorded in the unit history
+func (s *DaemonServer) checkUnitHealth(unit *Unit) {
+	model := unit.ModelCopy()
+	interval := s.unitHealthInterval(&model)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-unit.Done():
+			return
+		case <-ticker.C:
+		}
+
+		health := UnitHealthHealthy
+		err := runHealthCommand(&model, unit.env, interval)
+
+		if err != nil {
+			health = UnitHealthUnhealthy
+		}
+
+		select {
+		case <-unit.Done():
+			return
+		default:
+		}
+
+		if UnitHealth(unit.health.Swap(uint32(health))) == health {
+			continue
+		}
+
+		slog.Info("unit health changed", "id", unit.Model.ID, "health", health)
+		event := &UnitEventModel{
+			Type:   UnitEventHealth,
+			PID:    unit.Process.Pid,
+			Health: health.String(),
+		}
+
+		if err != nil {
+			event.Reason = err.Error()
+		}
+
+		s.recordUnitEvent(unit, event)
+	}
+}
+
+// runHealthCommand runs the health command with the unit cwd, env and credential.
+// The returned error includes the command output
+func runHealthCommand(model *UnitModel, env []string, timeout time.Duration) error {
+	credential, err := resolveUnitCredential(model)
+
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	command := exec.CommandContext(ctx, "/bin/sh", "-c", model.HealthCmd)
+	command.Dir = model.CWD
+	command.Env = env
+	command.SysProcAttr = &syscall.SysProcAttr{Credential: credential}
+
+	output, err := command.CombinedOutput()
+	return commandOutputError(err, output)
+}
+
+// commandOutputError adds the end of the command output to its error
+func commandOutputError(err error, output []byte) error {
+	if err == nil {
+		return nil
+	}
+
+	trimmedOutput := strings.TrimSpace(string(output))
+
+	if len(trimmedOutput) > commandOutputLimit {
+		trimmedOutput = trimmedOutput[len(trimmedOutput)-commandOutputLimit:]
+	}
+
+	if len(trimmedOutput) == 0 {
+		return err
+	}
+
+	return fmt.Errorf("%w: %s", err, trimmedOutput)
+}
@@ -0,0 +1,190 @@
+package daemon
+
+import (
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+)
+
+const (
+	UnitEventStarted    = "started"
+	UnitEventExited     = "exited"
+	UnitEventStopped    = "stopped"
+	UnitEventRestarting = "restarting"
+	UnitEventHealth     = "health"
+
+	historyQueueSize = 256
+)
+
+// UnitEventModel is a unit history entry. LogTail holds the last log lines
+// of the unit when it failed
+type UnitEventModel struct {
+	ID       uint64 `storm:"id,increment"`
+	UnitID   uint64 `storm:"index"`
+	Type     string
+	Time     time.Time
+	PID      int
+	ExitCode int
+	Signal   string
+	Duration time.Duration
+	Reason   string
+	By       string
+	Health   string
+	LogTail  []string
+}
+
+func (e *UnitEventModel) PB() *pb.UnitEvent {
+	return &pb.UnitEvent{
+		Id:         e.ID,
+		UnitId:     e.UnitID,
+		Type:       e.Type,
+		Time:       e.Time.UnixMilli(),
+		Pid:        int32(e.PID),
+		ExitCode:   int32(e.ExitCode),
+		Signal:     e.Signal,
+		DurationMs: e.Duration.Milliseconds(),
+		Reason:     e.Reason,
+		By:         e.By,
+		Health:     e.Health,
+		LogTail:    e.LogTail,
+	}
+}
+
+// writeHistory applies history writes in order. They are queued instead of
+// written in place, because the callers may hold the database open themselves
+func (s *DaemonServer) writeHistory() {
+	for write := range s.historyQueue {
+		db := s.Options.DBFactory()
+		write(db)
+		db.Close()
+		s.historyWrites.Done()
+	}
+}
+
+func (s *DaemonServer) queueHistoryWrite(write func(db *storm.DB)) {
+	s.historyWrites.Add(1)
+	s.historyQueue <- write
+}
+
+// recordUnitEvent saves the event in the unit history and publishes it
+func (s *DaemonServer) recordUnitEvent(unit *Unit, event *UnitEventModel) {
+	event.UnitID = unit.Model.ID
+	event.Time = time.Now()
+	maxEvents := s.getConfig().History.Events
+
+	s.publishUnitEvent(event.Type, unit, event)
+
+	s.queueHistoryWrite(func(db *storm.DB) {
+		// the unit could be deleted while the event was queued
+		if err := db.One("ID", event.UnitID, &UnitModel{}); err != nil {
+			return
+		}
+
+		// a copy is saved, the hooks read the event while Save sets its id
+		savedEvent := *event
+
+		if err := db.Save(&savedEvent); err != nil {
+			slog.Error("save unit event", "id", event.UnitID, "err", err)
+			return
+		}
+
+		var staleEvents []UnitEventModel
+
+		db.Select(q.Eq("UnitID", event.UnitID)).
+			OrderBy("ID").
+			Reverse().
+			Skip(maxEvents).
+			Find(&staleEvents)
+
+		for _, staleEvent := range staleEvents {
+			db.DeleteStruct(&staleEvent)
+		}
+	})
+}
+
+func (s *DaemonServer) deleteUnitHistory(unitID uint64) {
+	s.queueHistoryWrite(func(db *storm.DB) {
+		db.Select(q.Eq("UnitID", unitID)).Delete(&UnitEventModel{})
+	})
+}
+
+// getUnitHistory returns the last unit events, newest first
+func (s *DaemonServer) getUnitHistory(unitID uint64, limit int) ([]UnitEventModel, error) {
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	var events []UnitEventModel
+
+	err := db.Select(q.Eq("UnitID", unitID)).
+		OrderBy("ID").
+		Reverse().
+		Limit(limit).
+		Find(&events)
+
+	if err == storm.ErrNotFound {
+		return nil, nil
+	}
+
+	return events, err
+}
+
+func (s *DaemonServer) readUnitLogTail(unitID uint64, lines int) []string {
+	if lines <= 0 {
+		return nil
+	}
+
+	logFile, err := os.Open(s.getUnitLogFilepath(unitID))
+
+	if err != nil {
+		return nil
+	}
+
+	defer logFile.Close()
+
+	logTail := make([]string, 0, lines)
+
+	tailLogFile(logFile, uint64(lines), func(line string) error {
+		logTail = append(logTail, line)
+		return nil
+	})
+
+	slices.Reverse(logTail)
+	return logTail
+}
+
+func (s *DaemonServer) unitExitEvent(unit *Unit, unitStatus UnitStatus) *UnitEventModel {
+	event := &UnitEventModel{
+		UnitID:   unit.Model.ID,
+		Type:     UnitEventExited,
+		PID:      unit.Process.Pid,
+		ExitCode: unit.exitCode,
+		Duration: time.Since(unit.StartedAt),
+	}
+
+	if unit.exitSignal != 0 {
+		event.Signal = SignalName(unit.exitSignal)
+	}
+
+	if unit.Adopted {
+		event.Reason = "adopted process exited, exit code is unknown"
+	}
+
+	switch unitStatus {
+	case UnitStatusStopped:
+		event.Type = UnitEventStopped
+
+		if stop := unit.stop.Load(); stop != nil {
+			event.Reason = stop.reason
+			event.By = stop.by
+		}
+	case UnitStatusFailed:
+		event.LogTail = s.readUnitLogTail(unit.Model.ID, s.getConfig().History.LogLines)
+	}
+
+	return event
+}
@@ -0,0 +1,310 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+const (
+	HookPhasePreStart  = "pre-start"
+	HookPhasePostStart = "post-start"
+	HookPhasePreStop   = "pre-stop"
+	HookPhasePostExit  = "post-exit"
+
+	defaultHookTimeout  = 10 * time.Second
+	hookSignatureHeader = "X-PM0-Signature"
+	hookPhaseHeader     = "X-PM0-Phase"
+)
+
+// hookRetryDelay is the delay before the first retry of a webhook, it's
+// doubled for every next one
+var hookRetryDelay = time.Second
+
+func ParseHookPhase(phase string) (string, error) {
+	switch phase {
+	case HookPhasePreStart, HookPhasePostStart, HookPhasePreStop, HookPhasePostExit:
+		return phase, nil
+	default:
+		return "", fmt.Errorf(
+			"unknown hook phase %q, expected %s, %s, %s or %s",
+			phase,
+			HookPhasePreStart,
+			HookPhasePostStart,
+			HookPhasePreStop,
+			HookPhasePostExit,
+		)
+	}
+}
+
+func (h *HookConfig) Validate() error {
+	if _, err := ParseHookPhase(h.Phase); err != nil {
+		return err
+	}
+
+	if (len(h.URL) > 0) == (len(h.Command) > 0) {
+		return errors.New("hook should have either a url or a command")
+	}
+
+	if len(h.URL) > 0 {
+		hookURL, err := url.Parse(h.URL)
+
+		if err != nil {
+			return err
+		}
+
+		if hookURL.Scheme != "http" && hookURL.Scheme != "https" {
+			return fmt.Errorf("hook url %q should be http or https", h.URL)
+		}
+	}
+
+	if h.Retries < 0 {
+		return errors.New("hook retries can't be negative")
+	}
+
+	return nil
+}
+
+// ParseUnitHook parses "<phase>=<command or url>" hooks of the start request
+func ParseUnitHook(hook string) (HookConfig, error) {
+	phase, target, ok := strings.Cut(hook, "=")
+
+	if !ok || len(target) == 0 {
+		return HookConfig{}, fmt.Errorf("hook %q should be formatted as <phase>=<command or url>", hook)
+	}
+
+	hookConfig := HookConfig{Phase: phase, Command: target}
+
+	if strings.HasPrefix(target, "http://") || strings.HasPrefix(target, "https://") {
+		hookConfig = HookConfig{Phase: phase, URL: target}
+	}
+
+	return hookConfig, hookConfig.Validate()
+}
+
+func (h HookConfig) String() string {
+	if len(h.URL) > 0 {
+		return h.Phase + "=" + h.URL
+	}
+
+	return h.Phase + "=" + h.Command
+}
+
+type hookPayload struct {
+	Phase    string        `json:"phase"`
+	Time     time.Time     `json:"time"`
+	Hostname string        `json:"hostname"`
+	Unit     *pb.Unit      `json:"unit"`
+	Exit     *pb.UnitEvent `json:"exit,omitempty"`
+}
+
+// runHooks runs the daemon hooks selecting the unit and then the unit hooks
+// of the phase one by one. It stops at the first failed hook. Unit command
+// hooks run with the resolved env of the unit
+func (s *DaemonServer) runHooks(
+	phase string,
+	model *UnitModel,
+	env []string,
+	unit *pb.Unit,
+	exitEvent *UnitEventModel,
+) error {
+	payload := &hookPayload{
+		Phase: phase,
+		Time:  time.Now(),
+		Unit:  unit,
+	}
+
+	payload.Hostname, _ = os.Hostname()
+	failed := false
+
+	if exitEvent != nil {
+		payload.Exit = exitEvent.PB()
+		failed = exitEvent.Type == UnitEventExited && (exitEvent.ExitCode != 0 || len(exitEvent.Signal) > 0)
+	}
+
+	for _, hook := range s.getConfig().Hooks {
+		if hook.Phase != phase || (hook.FailuresOnly && !failed) {
+			continue
+		}
+
+		if !UnitMatchesSelectors(unit, hook.Selectors) {
+			continue
+		}
+
+		if err := runHook(&hook, nil, nil, payload); err != nil {
+			return fmt.Errorf("%s: %w", hook, err)
+		}
+	}
+
+	for _, hook := range model.Hooks {
+		if hook.Phase != phase {
+			continue
+		}
+
+		if err := runHook(&hook, model, env, payload); err != nil {
+			return fmt.Errorf("%s: %w", hook, err)
+		}
+	}
+
+	return nil
+}
+
+// runHooksInBackground is used for the phases that can't block the unit
+func (s *DaemonServer) runHooksInBackground(phase string, unit *Unit, exitEvent *UnitEventModel) {
+	pbUnit := unit.PB()
+	model := unit.ModelCopy()
+	s.hookRuns.Add(1)
+
+	go func() {
+		defer s.hookRuns.Done()
+
+		if err := s.runHooks(phase, &model, unit.env, pbUnit, exitEvent); err != nil {
+			slog.Error("hook failed", "id", unit.Model.ID, "phase", phase, "err", err)
+		}
+	}()
+}
+
+// runHook runs a daemon hook if model is nil, or a hook of the unit
+func runHook(hook *HookConfig, model *UnitModel, env []string, payload *hookPayload) error {
+	timeout := time.Duration(hook.Timeout)
+
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+
+	if len(hook.URL) == 0 {
+		return runCommandHook(hook, model, env, payload, timeout)
+	}
+
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := postWebhook(hook, body, timeout)
+
+		if err == nil || attempt >= hook.Retries {
+			return err
+		}
+
+		time.Sleep(hookRetryDelay << attempt)
+	}
+}
+
+// postWebhook signs the body with HMAC-SHA256 of the hook secret, if it's set
+func postWebhook(hook *HookConfig, body []byte, timeout time.Duration) error {
+	request, err := http.NewRequest(http.MethodPost, hook.URL, bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", "pm0")
+	request.Header.Set(hookPhaseHeader, hook.Phase)
+
+	if len(hook.Secret) > 0 {
+		mac := hmac.New(sha256.New, []byte(hook.Secret))
+		mac.Write(body)
+		request.Header.Set(hookSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	client := http.Client{Timeout: timeout}
+	response, err := client.Do(request)
+
+	if err != nil {
+		return err
+	}
+
+	response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", response.Status)
+	}
+
+	return nil
+}
+
+// runCommandHook runs daemon hooks with the daemon env, and unit hooks with
+// the unit cwd, env and credential. Event details are passed in PM0_ env vars
+func runCommandHook(
+	hook *HookConfig,
+	model *UnitModel,
+	unitEnv []string,
+	payload *hookPayload,
+	timeout time.Duration,
+) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	// the timeout kills the process group, the children of the shell could
+	// keep the output open
+	command := exec.CommandContext(ctx, "/bin/sh", "-c", hook.Command)
+	command.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	command.WaitDelay = execWaitDelay
+	command.Cancel = func() error {
+		return syscall.Kill(-command.Process.Pid, syscall.SIGKILL)
+	}
+
+	env := os.Environ()
+
+	if model != nil {
+		credential, err := resolveUnitCredential(model)
+
+		if err != nil {
+			return err
+		}
+
+		command.Dir = model.CWD
+		command.SysProcAttr.Credential = credential
+		env = unitEnv
+	}
+
+	payloadJSON, err := json.Marshal(payload)
+
+	if err != nil {
+		return err
+	}
+
+	// the unit env is shared with the health checks and the other hooks, the
+	// vars are appended to a copy
+	command.Env = slices.Concat(env, []string{
+		"PM0_HOOK_PHASE=" + payload.Phase,
+		"PM0_HOOK_PAYLOAD=" + string(payloadJSON),
+		"PM0_UNIT_ID=" + strconv.FormatUint(payload.Unit.Id, 10),
+		"PM0_UNIT_NAME=" + payload.Unit.Name,
+		"PM0_UNIT_PID=" + strconv.Itoa(int(payload.Unit.Pid)),
+		"PM0_UNIT_STATUS=" + UnitStatus(payload.Unit.Status).String(),
+		"PM0_UNIT_RESTARTS=" + strconv.FormatUint(uint64(payload.Unit.RestartsCount), 10),
+	})
+
+	if payload.Exit != nil {
+		command.Env = append(
+			command.Env,
+			"PM0_EXIT_CODE="+strconv.Itoa(int(payload.Exit.ExitCode)),
+			"PM0_EXIT_SIGNAL="+payload.Exit.Signal,
+		)
+	}
+
+	output, err := command.CombinedOutput()
+	return commandOutputError(err, output)
+}
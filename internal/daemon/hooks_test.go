@@ -0,0 +1,308 @@
+package daemon
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+// webhookRequest is a request received by the stand-in webhook server
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// newWebhookServer starts a stand-in webhook server responding with the
+// statuses in order, the last one is repeated
+func newWebhookServer(t *testing.T, statuses ...int) (*httptest.Server, func() []webhookRequest) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []webhookRequest
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		requests = append(requests, webhookRequest{header: r.Header.Clone(), body: body})
+		status := statuses[min(len(requests), len(statuses))-1]
+		mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server, func() []webhookRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]webhookRequest(nil), requests...)
+	}
+}
+
+func setHookRetryDelay(t *testing.T, delay time.Duration) {
+	previousDelay := hookRetryDelay
+	hookRetryDelay = delay
+
+	t.Cleanup(func() {
+		hookRetryDelay = previousDelay
+	})
+}
+
+func testHookPayload() *hookPayload {
+	return &hookPayload{
+		Phase: HookPhasePostStart,
+		Time:  time.Now(),
+		Unit:  &pb.Unit{Id: 1, Name: "web"},
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	server, requests := newWebhookServer(t, http.StatusOK)
+	hook := HookConfig{Phase: HookPhasePostStart, URL: server.URL, Secret: "hook secret"}
+
+	if err := runHook(&hook, nil, nil, testHookPayload()); err != nil {
+		t.Fatal(err)
+	}
+
+	received := requests()
+
+	if len(received) != 1 {
+		t.Fatalf("got %d requests, expected 1", len(received))
+	}
+
+	mac := hmac.New(sha256.New, []byte(hook.Secret))
+	mac.Write(received[0].body)
+	expected := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	if signature := received[0].header.Get(hookSignatureHeader); signature != expected {
+		t.Fatalf("signature %q, expected %q", signature, expected)
+	}
+
+	if phase := received[0].header.Get(hookPhaseHeader); phase != HookPhasePostStart {
+		t.Fatalf("phase header %q, expected %q", phase, HookPhasePostStart)
+	}
+
+	var payload struct {
+		Phase string   `json:"phase"`
+		Unit  *pb.Unit `json:"unit"`
+	}
+
+	if err := json.Unmarshal(received[0].body, &payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if payload.Phase != HookPhasePostStart || payload.Unit.Name != "web" {
+		t.Fatalf("unexpected payload %s", received[0].body)
+	}
+}
+
+func TestWebhookWithoutSecretIsNotSigned(t *testing.T) {
+	server, requests := newWebhookServer(t, http.StatusNoContent)
+	hook := HookConfig{Phase: HookPhasePostStart, URL: server.URL}
+
+	if err := runHook(&hook, nil, nil, testHookPayload()); err != nil {
+		t.Fatal(err)
+	}
+
+	if signature := requests()[0].header.Get(hookSignatureHeader); len(signature) > 0 {
+		t.Fatalf("unsigned hook sent signature %q", signature)
+	}
+}
+
+func TestWebhookRetries(t *testing.T) {
+	const retryDelay = 20 * time.Millisecond
+
+	setHookRetryDelay(t, retryDelay)
+
+	tests := []struct {
+		name     string
+		retries  int
+		statuses []int
+		requests int
+		fails    bool
+	}{
+		{"success", 2, []int{http.StatusOK}, 1, false},
+		{"retried 5xx", 2, []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusOK}, 3, false},
+		{"retries exhausted", 1, []int{http.StatusServiceUnavailable}, 2, true},
+		{"no retries", 0, []int{http.StatusInternalServerError}, 1, true},
+		{"4xx", 0, []int{http.StatusNotFound}, 1, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, requests := newWebhookServer(t, test.statuses...)
+			hook := HookConfig{Phase: HookPhasePostStart, URL: server.URL, Retries: test.retries}
+
+			start := time.Now()
+			err := runHook(&hook, nil, nil, testHookPayload())
+			elapsed := time.Since(start)
+
+			if (err != nil) != test.fails {
+				t.Fatalf("err %v, expected failure %t", err, test.fails)
+			}
+
+			if received := len(requests()); received != test.requests {
+				t.Fatalf("got %d requests, expected %d", received, test.requests)
+			}
+
+			// the delay doubles for every retry
+			var backoff time.Duration
+
+			for attempt := range test.requests - 1 {
+				backoff += retryDelay << attempt
+			}
+
+			if elapsed < backoff {
+				t.Fatalf("retried after %s, expected a backoff of %s", elapsed, backoff)
+			}
+		})
+	}
+}
+
+func TestWebhookTimeout(t *testing.T) {
+	setHookRetryDelay(t, time.Millisecond)
+
+	var attempts atomic.Int32
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+
+	t.Cleanup(server.Close)
+	t.Cleanup(func() { close(release) })
+
+	hook := HookConfig{
+		Phase:   HookPhasePostStart,
+		URL:     server.URL,
+		Retries: 1,
+		Timeout: Duration(50 * time.Millisecond),
+	}
+
+	start := time.Now()
+
+	if err := runHook(&hook, nil, nil, testHookPayload()); err == nil {
+		t.Fatal("the hook didn't time out")
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("the hook took %s, expected the timeout of every attempt", elapsed)
+	}
+
+	if attempts.Load() != 2 {
+		t.Fatalf("got %d attempts, expected the timed out request to be retried", attempts.Load())
+	}
+}
+
+func TestCommandHookTimeout(t *testing.T) {
+	hook := HookConfig{Phase: HookPhasePostStart, Command: "sleep 5", Timeout: Duration(50 * time.Millisecond)}
+	start := time.Now()
+
+	if err := runHook(&hook, nil, nil, testHookPayload()); err == nil {
+		t.Fatal("the hook didn't time out")
+	}
+
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("the hook took %s, expected it to be killed after the timeout", elapsed)
+	}
+}
+
+func TestRunHooksFilters(t *testing.T) {
+	failedExit := &UnitEventModel{Type: UnitEventExited, ExitCode: 1}
+	cleanExit := &UnitEventModel{Type: UnitEventExited}
+	signaledExit := &UnitEventModel{Type: UnitEventExited, Signal: "SIGKILL"}
+	stop := &UnitEventModel{Type: UnitEventStopped, Signal: "SIGTERM"}
+
+	web := &pb.Unit{Id: 1, Name: "web", Labels: map[string]string{"app": "web"}}
+	db := &pb.Unit{Id: 2, Name: "db", Labels: map[string]string{"app": "db"}}
+
+	tests := []struct {
+		name         string
+		failuresOnly bool
+		selectors    []string
+		unit         *pb.Unit
+		exit         *UnitEventModel
+		called       bool
+	}{
+		{"any exit", false, nil, web, cleanExit, true},
+		{"failures only, failed", true, nil, web, failedExit, true},
+		{"failures only, clean exit", true, nil, web, cleanExit, false},
+		{"failures only, signaled", true, nil, web, signaledExit, true},
+		{"failures only, stopped", true, nil, web, stop, false},
+		{"label selected", false, []string{"app=web"}, web, cleanExit, true},
+		{"label not selected", false, []string{"app=web"}, db, cleanExit, false},
+		{"name glob", false, []string{"d*"}, db, cleanExit, true},
+		{"one of the selectors", false, []string{"app=api", "2"}, db, cleanExit, true},
+		{"selected failure", true, []string{"app=web"}, web, failedExit, true},
+		{"not selected failure", true, []string{"app=web"}, db, failedExit, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server, requests := newWebhookServer(t, http.StatusOK)
+			s := &DaemonServer{config: Config{Hooks: []HookConfig{{
+				Phase:        HookPhasePostExit,
+				URL:          server.URL,
+				Selectors:    test.selectors,
+				FailuresOnly: test.failuresOnly,
+			}}}}
+
+			if err := s.runHooks(HookPhasePostExit, &UnitModel{}, nil, test.unit, test.exit); err != nil {
+				t.Fatal(err)
+			}
+
+			if called := len(requests()) > 0; called != test.called {
+				t.Fatalf("hook called %t, expected %t", called, test.called)
+			}
+		})
+	}
+}
+
+func TestRunHooksPhase(t *testing.T) {
+	server, requests := newWebhookServer(t, http.StatusOK)
+	s := &DaemonServer{config: Config{Hooks: []HookConfig{{Phase: HookPhasePreStop, URL: server.URL}}}}
+
+	if err := s.runHooks(HookPhasePostStart, &UnitModel{}, nil, &pb.Unit{Id: 1}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requests()) > 0 {
+		t.Fatal("the pre-stop hook was called for post-start")
+	}
+}
+
+func TestRunHooksStopsAtFailure(t *testing.T) {
+	failing, _ := newWebhookServer(t, http.StatusInternalServerError)
+	next, requests := newWebhookServer(t, http.StatusOK)
+
+	s := &DaemonServer{config: Config{Hooks: []HookConfig{
+		{Phase: HookPhasePreStart, URL: failing.URL},
+		{Phase: HookPhasePreStart, URL: next.URL},
+	}}}
+
+	if err := s.runHooks(HookPhasePreStart, &UnitModel{}, nil, &pb.Unit{Id: 1}, nil); err == nil {
+		t.Fatal("the failed hook wasn't reported")
+	}
+
+	if len(requests()) > 0 {
+		t.Fatal("the hook after the failed one was called")
+	}
+}
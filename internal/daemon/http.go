@@ -0,0 +1,405 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// eventStreamKeepAlive is the interval of the comments sent to the idle
+// server-sent event streams
+const eventStreamKeepAlive = 15 * time.Second
+
+// maxRequestBodySize limits the JSON bodies of the HTTP requests
+const maxRequestBodySize = 1 << 20
+
+// streamFormat is the encoding of the responses of the streaming RPCs
+type streamFormat struct {
+	contentType string
+	message     string // format of a message, its JSON is the argument
+	err         string // format of an error after the first message
+	keepAlive   string // sent on idle streams, empty if the format has no comments
+}
+
+var (
+	sseStreamFormat = streamFormat{
+		contentType: "text/event-stream",
+		message:     "data: %s\n\n",
+		err:         "event: error\ndata: %s\n\n",
+		keepAlive:   ": keep-alive\n\n",
+	}
+
+	ndjsonStreamFormat = streamFormat{
+		contentType: "application/x-ndjson",
+		message:     "%s\n",
+		err:         "%s\n",
+	}
+)
+
+// requestStreamFormat returns server-sent events to the clients accepting
+// them, like browsers, and NDJSON to the others
+func requestStreamFormat(r *http.Request) streamFormat {
+	if strings.Contains(r.Header.Get("Accept"), sseStreamFormat.contentType) {
+		return sseStreamFormat
+	}
+
+	return ndjsonStreamFormat
+}
+
+var httpStatusCodes = map[codes.Code]int{
+	codes.OK:                 http.StatusOK,
+	codes.Canceled:           499,
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.DeadlineExceeded:   http.StatusGatewayTimeout,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+	codes.FailedPrecondition: http.StatusConflict,
+	codes.Aborted:            http.StatusConflict,
+	codes.OutOfRange:         http.StatusBadRequest,
+	codes.Unimplemented:      http.StatusNotImplemented,
+	codes.Unavailable:        http.StatusServiceUnavailable,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+}
+
+func httpStatusCode(code codes.Code) int {
+	if statusCode, ok := httpStatusCodes[code]; ok {
+		return statusCode
+	}
+
+	return http.StatusInternalServerError
+}
+
+// protoJSON keeps the proto field names and the zero values, the running
+// status of a unit is 0
+var protoJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+type httpError struct {
+	Error string `json:"error"`
+	Code  string `json:"code"`
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeProtoJSON(w http.ResponseWriter, statusCode int, message proto.Message) {
+	body, err := protoJSON.Marshal(message)
+
+	if err != nil {
+		writeHTTPError(w, status.Error(codes.Internal, err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	w.Write(body)
+}
+
+// writeHTTPError responds with the HTTP status of the grpc status code
+func writeHTTPError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeJSON(w, httpStatusCode(st.Code()), httpError{Error: st.Message(), Code: st.Code().String()})
+}
+
+// authenticated checks the request like a call of the grpc method, the
+// handler gets the request with the context of the caller
+func authenticated(
+	authenticator *Authenticator,
+	fullMethod string,
+	handler http.HandlerFunc,
+) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := checkBrowserRequest(r); err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+
+		ctx, err := authenticator.authenticateRequest(r, fullMethod)
+
+		if err != nil {
+			writeHTTPError(w, err)
+			return
+		}
+
+		handler(w, r.WithContext(ctx))
+	}
+}
+
+// checkBrowserRequest refuses the requests a browser could send on behalf of
+// another site. The changes need a JSON content type, which a form can't send,
+// and a same-origin Origin. A loopback listener only answers to a loopback
+// Host, or a rebound domain of another site would reach it
+func checkBrowserRequest(r *http.Request) error {
+	if httpConnInfo(r).isLoopback() && !isLoopbackHost(r.Host) {
+		return status.Errorf(codes.PermissionDenied, "unexpected host %q of a loopback listener", r.Host)
+	}
+
+	if r.Method == http.MethodGet || r.Method == http.MethodHead {
+		return nil
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		return status.Errorf(codes.InvalidArgument, "%s requests need the application/json content type", r.Method)
+	}
+
+	if origin := r.Header.Get("Origin"); len(origin) > 0 {
+		originURL, err := url.Parse(origin)
+
+		if err != nil || originURL.Host != r.Host {
+			return status.Errorf(codes.PermissionDenied, "cross-origin request from %s", origin)
+		}
+	}
+
+	return nil
+}
+
+// isLoopbackHost reports whether the Host header names a loopback address,
+// with or without a port
+func isLoopbackHost(host string) bool {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+
+	host = strings.TrimSuffix(strings.TrimPrefix(host, "["), "]")
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// decodeProtoJSON reads the JSON body of the request into the message,
+// unknown fields are rejected
+func decodeProtoJSON(w http.ResponseWriter, r *http.Request, message proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestBodySize))
+
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "read request body: %s", err)
+	}
+
+	if err := protojson.Unmarshal(body, message); err != nil {
+		return status.Errorf(codes.InvalidArgument, "malformed request body: %s", err)
+	}
+
+	return nil
+}
+
+func parseUnitIDPathValue(r *http.Request) (uint64, error) {
+	value := r.PathValue("id")
+	unitID, err := strconv.ParseUint(value, 10, 64)
+
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid unit id %q", value)
+	}
+
+	return unitID, nil
+}
+
+// localStream is the server side of a streaming RPC called in-process, like
+// from the HTTP handlers. The responses are passed to send one at a time
+type localStream[T any] struct {
+	ctx  context.Context
+	mu   sync.Mutex
+	send func(response *T) error
+}
+
+func newLocalStream[T any](ctx context.Context, send func(response *T) error) *localStream[T] {
+	return &localStream[T]{ctx: ctx, send: send}
+}
+
+func (s *localStream[T]) Send(response *T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return s.send(response)
+}
+
+func (s *localStream[T]) Context() context.Context {
+	return s.ctx
+}
+
+func (s *localStream[T]) SendMsg(m any) error {
+	return s.Send(m.(*T))
+}
+
+func (s *localStream[T]) RecvMsg(m any) error {
+	return io.EOF
+}
+
+func (s *localStream[T]) SetHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localStream[T]) SendHeader(metadata.MD) error {
+	return nil
+}
+
+func (s *localStream[T]) SetTrailer(metadata.MD) {}
+
+type unitResults struct {
+	Results []json.RawMessage `json:"results"`
+}
+
+// serveUnitsStream calls an RPC streaming the results of unit actions like
+// stop and responds with all of them. The status is the one of the first
+// failed unit, so a single unit request fails like a unary call
+func serveUnitsStream(
+	w http.ResponseWriter,
+	r *http.Request,
+	call func(stream pb.ProcessService_StopServer) error,
+) {
+	var (
+		results    unitResults
+		statusCode = http.StatusOK
+	)
+
+	stream := newLocalStream(r.Context(), func(response *pb.StopResponse) error {
+		result, err := protoJSON.Marshal(response)
+
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		if response.Code != uint32(codes.OK) && statusCode == http.StatusOK {
+			statusCode = httpStatusCode(codes.Code(response.Code))
+		}
+
+		results.Results = append(results.Results, result)
+		return nil
+	})
+
+	if err := call(stream); err != nil {
+		writeHTTPError(w, err)
+		return
+	}
+
+	writeJSON(w, statusCode, results)
+}
+
+// serveStream calls a server streaming RPC and streams its responses in the
+// format the client accepts. Errors before the first response are HTTP
+// errors, the later ones are sent as the last message
+func serveStream[T any, P interface {
+	*T
+	proto.Message
+}](
+	w http.ResponseWriter,
+	r *http.Request,
+	call func(stream grpc.ServerStreamingServer[T]) error,
+) {
+	format := requestStreamFormat(r)
+	controller := http.NewResponseController(w)
+	started := false
+
+	start := func() {
+		started = true
+		w.Header().Set("Content-Type", format.contentType)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+	}
+
+	stream := newLocalStream(r.Context(), func(response *T) error {
+		data, err := protoJSON.Marshal(P(response))
+
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		if !started {
+			start()
+		}
+
+		if _, err := fmt.Fprintf(w, format.message, data); err != nil {
+			return err
+		}
+
+		return controller.Flush()
+	})
+
+	keepAliveDone := make(chan struct{})
+	keepAliveStopped := make(chan struct{})
+
+	// the comments keep idle streams like events open through proxies
+	go func() {
+		defer close(keepAliveStopped)
+
+		if len(format.keepAlive) == 0 {
+			return
+		}
+
+		ticker := time.NewTicker(eventStreamKeepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-keepAliveDone:
+				return
+			case <-ticker.C:
+				stream.mu.Lock()
+
+				if !started {
+					start()
+				}
+
+				io.WriteString(w, format.keepAlive)
+				controller.Flush()
+				stream.mu.Unlock()
+			}
+		}
+	}()
+
+	err := call(stream)
+	close(keepAliveDone)
+	<-keepAliveStopped
+
+	// the client went away, nobody reads the error
+	if r.Context().Err() != nil {
+		return
+	}
+
+	if err == nil {
+		if !started {
+			start()
+		}
+
+		return
+	}
+
+	if !started {
+		writeHTTPError(w, err)
+		return
+	}
+
+	st := status.Convert(err)
+	data, _ := json.Marshal(httpError{Error: st.Message(), Code: st.Code().String()})
+	fmt.Fprintf(w, format.err, data)
+	controller.Flush()
+}
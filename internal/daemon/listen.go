@@ -0,0 +1,88 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net"
+	"os"
+	"os/user"
+	"strconv"
+	"syscall"
+)
+
+const (
+	socketFilePerm  = 0o660
+	socketUmask     = 0o777 &^ socketFilePerm
+	socketGroupName = "pm0"
+)
+
+// ListenUnix listens on the daemon socket, replacing a stale socket file left
+// by a previous daemon. Only the owner and members of the pm0 group (if such
+// group exists) may connect to the socket
+func ListenUnix(socketFilepath string) (net.Listener, error) {
+	if _, err := os.Stat(socketFilepath); err == nil {
+		if conn, err := net.Dial("unix", socketFilepath); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another daemon is listening on %s", socketFilepath)
+		}
+
+		if err := os.Remove(socketFilepath); err != nil {
+			return nil, err
+		}
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	// the socket is created with the permissions of the umask, it's narrowed
+	// during the listen so the socket is never reachable by the others. The
+	// listeners are created on startup, before the units and hooks run
+	umask := syscall.Umask(socketUmask)
+	lis, err := net.Listen("unix", socketFilepath)
+	syscall.Umask(umask)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.Chmod(socketFilepath, socketFilePerm); err != nil {
+		lis.Close()
+		return nil, err
+	}
+
+	group, err := user.LookupGroup(socketGroupName)
+
+	if err != nil {
+		return lis, nil
+	}
+
+	gid, err := strconv.Atoi(group.Gid)
+
+	if err != nil {
+		return lis, nil
+	}
+
+	if err := os.Chown(socketFilepath, -1, gid); err != nil {
+		slog.Warn("chown socket", "group", socketGroupName, "err", err)
+	}
+
+	return lis, nil
+}
+
+// IsLoopbackAddress reports whether the host:port address only accepts local
+// connections. An empty host listens on every interface
+func IsLoopbackAddress(address string) bool {
+	host, _, err := net.SplitHostPort(address)
+
+	if err != nil || len(host) == 0 {
+		return false
+	}
+
+	if host == "localhost" {
+		return true
+	}
+
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
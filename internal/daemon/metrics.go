@@ -0,0 +1,110 @@
+package daemon
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var unitStatusNames = map[UnitStatus]string{
+	UnitStatusRunning: "running",
+	UnitStatusExited:  "exited",
+	UnitStatusFailed:  "failed",
+	UnitStatusStopped: "stopped",
+}
+
+func (status UnitStatus) String() string {
+	if name, ok := unitStatusNames[status]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+func writeMetricHeader(w io.Writer, name string, metricType string, help string) {
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, metricType)
+}
+
+func unitMetricLabels(unit *Unit) string {
+	return fmt.Sprintf(`id="%d",name=%s`, unit.Model.ID, strconv.Quote(unit.Name()))
+}
+
+// MetricsHandler serves unit metrics in the prometheus text format
+func (s *DaemonServer) MetricsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.unitsMu.RLock()
+		units := make([]*Unit, 0, len(s.units))
+
+		for _, unit := range s.units {
+			units = append(units, unit)
+		}
+
+		s.unitsMu.RUnlock()
+
+		slices.SortFunc(units, func(a *Unit, b *Unit) int {
+			return cmp.Compare(a.Model.ID, b.Model.ID)
+		})
+
+		statusCounts := make(map[UnitStatus]int, len(unitStatusNames))
+		var body strings.Builder
+
+		writeMetricHeader(&body, "pm0_unit_up", "gauge", "Whether the unit is running.")
+
+		for _, unit := range units {
+			unitStatus := unit.Status()
+			statusCounts[unitStatus]++
+			up := 0
+
+			if unitStatus == UnitStatusRunning {
+				up = 1
+			}
+
+			fmt.Fprintf(&body, "pm0_unit_up{%s} %d\n", unitMetricLabels(unit), up)
+		}
+
+		writeMetricHeader(&body, "pm0_unit_restarts_total", "counter", "Unit restarts count.")
+
+		for _, unit := range units {
+			fmt.Fprintf(
+				&body,
+				"pm0_unit_restarts_total{%s} %d\n",
+				unitMetricLabels(unit),
+				unit.ModelCopy().RestartsCount,
+			)
+		}
+
+		writeMetricHeader(&body, "pm0_unit_uptime_seconds", "gauge", "Seconds since the unit start.")
+
+		for _, unit := range units {
+			if unit.Status() != UnitStatusRunning {
+				continue
+			}
+
+			fmt.Fprintf(
+				&body,
+				"pm0_unit_uptime_seconds{%s} %.0f\n",
+				unitMetricLabels(unit),
+				time.Since(unit.StartedAt).Seconds(),
+			)
+		}
+
+		writeMetricHeader(&body, "pm0_units", "gauge", "Units count by status.")
+
+		for _, unitStatus := range []UnitStatus{
+			UnitStatusRunning,
+			UnitStatusExited,
+			UnitStatusFailed,
+			UnitStatusStopped,
+		} {
+			fmt.Fprintf(&body, "pm0_units{status=%q} %d\n", unitStatus, statusCounts[unitStatus])
+		}
+
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		io.WriteString(w, body.String())
+	})
+}
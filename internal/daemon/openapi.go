@@ -0,0 +1,272 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const openAPISchemaRef = "#/components/schemas/"
+
+// openAPIFieldMaskDescription explains the JSON form of google.protobuf.FieldMask
+const openAPIFieldMaskDescription = "comma separated field paths in lowerCamelCase, like env,stripAnsi"
+
+// OpenAPIDocument describes the HTTP API in OpenAPI 3. The schemas are
+// generated from the proto messages in their JSON mapping with the proto
+// field names, 64-bit integers are strings
+func OpenAPIDocument() ([]byte, error) {
+	schemas := map[string]any{
+		"Error": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"error": map[string]any{"type": "string"},
+				"code":  map[string]any{"type": "string", "description": "grpc status code name"},
+			},
+		},
+	}
+
+	schemas["UnitResults"] = map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"results": map[string]any{
+				"type":  "array",
+				"items": openAPIMessageSchema((&pb.StopResponse{}).ProtoReflect().Descriptor(), schemas),
+			},
+		},
+	}
+
+	paths := map[string]any{}
+
+	for _, route := range gatewayRoutes {
+		pathItem, ok := paths[route.path].(map[string]any)
+
+		if !ok {
+			pathItem = map[string]any{}
+			paths[route.path] = pathItem
+		}
+
+		pathItem[strings.ToLower(route.method)] = openAPIOperation(route, schemas)
+	}
+
+	paths["/v1/openapi.json"] = map[string]any{
+		"get": map[string]any{
+			"operationId": "OpenAPI",
+			"summary":     "This document",
+			"security":    []any{},
+			"responses": map[string]any{
+				"200": map[string]any{
+					"description": "OpenAPI document",
+					"content":     map[string]any{"application/json": map[string]any{}},
+				},
+			},
+		},
+	}
+
+	document := map[string]any{
+		"openapi": "3.0.3",
+		"info": map[string]any{
+			"title":   "pm0 daemon API",
+			"version": "1",
+			"description": "HTTP/JSON API of the pm0 process service. Callers send the tokens of the " +
+				"grpc listeners as bearer tokens, read tokens can only call the read methods. " +
+				"Streams are NDJSON, or server-sent events when text/event-stream is accepted; " +
+				"an error after the first message is sent as the last message.",
+		},
+		"security": []any{map[string]any{"bearer": []any{}}},
+		"paths":    paths,
+		"components": map[string]any{
+			"securitySchemes": map[string]any{
+				"bearer": map[string]any{"type": "http", "scheme": "bearer"},
+			},
+			"schemas": schemas,
+		},
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func openAPIOperation(route gatewayRoute, schemas map[string]any) map[string]any {
+	var parameters []any
+
+	if strings.Contains(route.path, "{id}") {
+		parameters = append(parameters, map[string]any{
+			"name":     "id",
+			"in":       "path",
+			"required": true,
+			"schema":   map[string]any{"type": "integer", "format": "uint64"},
+		})
+	}
+
+	for _, param := range route.query {
+		schema := map[string]any{"type": param.kind}
+
+		if param.repeated {
+			schema = map[string]any{"type": "array", "items": schema}
+		}
+
+		parameters = append(parameters, map[string]any{
+			"name":        param.name,
+			"in":          "query",
+			"description": param.description,
+			"schema":      schema,
+		})
+	}
+
+	successStatus := route.status
+
+	if successStatus == 0 {
+		successStatus = http.StatusOK
+	}
+
+	var content map[string]any
+
+	switch {
+	case route.unitResults:
+		content = map[string]any{
+			"application/json": map[string]any{
+				"schema": map[string]any{"$ref": openAPISchemaRef + "UnitResults"},
+			},
+		}
+	case route.stream:
+		content = map[string]any{
+			ndjsonStreamFormat.contentType: map[string]any{
+				"schema": openAPIMessageSchema(route.response.ProtoReflect().Descriptor(), schemas),
+			},
+			sseStreamFormat.contentType: map[string]any{
+				"schema": map[string]any{
+					"type":        "string",
+					"description": "the messages as the data of the events, an error as an error event",
+				},
+			},
+		}
+	default:
+		content = map[string]any{
+			"application/json": map[string]any{
+				"schema": openAPIMessageSchema(route.response.ProtoReflect().Descriptor(), schemas),
+			},
+		}
+	}
+
+	description := "success"
+
+	if route.unitResults {
+		description = "the result of the unit, the status is the one of its error if it failed"
+	}
+
+	operation := map[string]any{
+		"operationId": route.rpc[strings.LastIndexByte(route.rpc, '/')+1:],
+		"summary":     route.summary,
+		"responses": map[string]any{
+			strconv.Itoa(successStatus): map[string]any{
+				"description": description,
+				"content":     content,
+			},
+			"default": map[string]any{
+				"description": "error with the HTTP status of its grpc status code",
+				"content": map[string]any{
+					"application/json": map[string]any{
+						"schema": map[string]any{"$ref": openAPISchemaRef + "Error"},
+					},
+				},
+			},
+		},
+	}
+
+	if len(parameters) > 0 {
+		operation["parameters"] = parameters
+	}
+
+	if route.body != nil {
+		operation["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{
+				"application/json": map[string]any{
+					"schema": openAPIMessageSchema(route.body.ProtoReflect().Descriptor(), schemas),
+				},
+			},
+		}
+	}
+
+	return operation
+}
+
+// openAPIMessageSchema adds the schema of the message and the messages of
+// its fields to schemas and returns a reference to it
+func openAPIMessageSchema(message protoreflect.MessageDescriptor, schemas map[string]any) map[string]any {
+	if message.FullName() == "google.protobuf.FieldMask" {
+		return map[string]any{"type": "string", "description": openAPIFieldMaskDescription}
+	}
+
+	name := string(message.Name())
+
+	if _, ok := schemas[name]; !ok {
+		// the placeholder stops the recursion of self-referencing messages
+		schemas[name] = nil
+		properties := map[string]any{}
+		fields := message.Fields()
+
+		for i := range fields.Len() {
+			field := fields.Get(i)
+			properties[string(field.Name())] = openAPIFieldSchema(field, schemas)
+		}
+
+		schemas[name] = map[string]any{"type": "object", "properties": properties}
+	}
+
+	return map[string]any{"$ref": openAPISchemaRef + name}
+}
+
+func openAPIFieldSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	if field.IsMap() {
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": openAPIValueSchema(field.MapValue(), schemas),
+		}
+	}
+
+	schema := openAPIValueSchema(field, schemas)
+
+	if field.IsList() {
+		return map[string]any{"type": "array", "items": schema}
+	}
+
+	return schema
+}
+
+func openAPIValueSchema(field protoreflect.FieldDescriptor, schemas map[string]any) map[string]any {
+	switch field.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "format": "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "format": "uint32"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return map[string]any{"type": "string", "format": "int64"}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return map[string]any{"type": "string", "format": "uint64"}
+	case protoreflect.FloatKind:
+		return map[string]any{"type": "number", "format": "float"}
+	case protoreflect.DoubleKind:
+		return map[string]any{"type": "number", "format": "double"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "format": "byte"}
+	case protoreflect.EnumKind:
+		values := field.Enum().Values()
+		names := make([]any, values.Len())
+
+		for i := range values.Len() {
+			names[i] = string(values.Get(i).Name())
+		}
+
+		return map[string]any{"type": "string", "enum": names}
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return openAPIMessageSchema(field.Message(), schemas)
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
@@ -20,18 +20,38 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProcessService_Start_FullMethodName      = "/pm0.ProcessService/Start"
-	ProcessService_List_FullMethodName       = "/pm0.ProcessService/List"
-	ProcessService_Stop_FullMethodName       = "/pm0.ProcessService/Stop"
-	ProcessService_StopAll_FullMethodName    = "/pm0.ProcessService/StopAll"
-	ProcessService_Restart_FullMethodName    = "/pm0.ProcessService/Restart"
-	ProcessService_RestartAll_FullMethodName = "/pm0.ProcessService/RestartAll"
-	ProcessService_Logs_FullMethodName       = "/pm0.ProcessService/Logs"
-	ProcessService_Delete_FullMethodName     = "/pm0.ProcessService/Delete"
-	ProcessService_DeleteAll_FullMethodName  = "/pm0.ProcessService/DeleteAll"
-	ProcessService_Show_FullMethodName       = "/pm0.ProcessService/Show"
-	ProcessService_LogsClear_FullMethodName  = "/pm0.ProcessService/LogsClear"
-	ProcessService_Update_FullMethodName     = "/pm0.ProcessService/Update"
+	ProcessService_Start_FullMethodName          = "/pm0.ProcessService/Start"
+	ProcessService_StartExisting_FullMethodName  = "/pm0.ProcessService/StartExisting"
+	ProcessService_List_FullMethodName           = "/pm0.ProcessService/List"
+	ProcessService_Stop_FullMethodName           = "/pm0.ProcessService/Stop"
+	ProcessService_StopAll_FullMethodName        = "/pm0.ProcessService/StopAll"
+	ProcessService_Restart_FullMethodName        = "/pm0.ProcessService/Restart"
+	ProcessService_RestartAll_FullMethodName     = "/pm0.ProcessService/RestartAll"
+	ProcessService_Logs_FullMethodName           = "/pm0.ProcessService/Logs"
+	ProcessService_Delete_FullMethodName         = "/pm0.ProcessService/Delete"
+	ProcessService_DeleteAll_FullMethodName      = "/pm0.ProcessService/DeleteAll"
+	ProcessService_Show_FullMethodName           = "/pm0.ProcessService/Show"
+	ProcessService_LogsClear_FullMethodName      = "/pm0.ProcessService/LogsClear"
+	ProcessService_Update_FullMethodName         = "/pm0.ProcessService/Update"
+	ProcessService_Config_FullMethodName         = "/pm0.ProcessService/Config"
+	ProcessService_History_FullMethodName        = "/pm0.ProcessService/History"
+	ProcessService_Events_FullMethodName         = "/pm0.ProcessService/Events"
+	ProcessService_SecretSet_FullMethodName      = "/pm0.ProcessService/SecretSet"
+	ProcessService_SecretGet_FullMethodName      = "/pm0.ProcessService/SecretGet"
+	ProcessService_SecretList_FullMethodName     = "/pm0.ProcessService/SecretList"
+	ProcessService_SecretDelete_FullMethodName   = "/pm0.ProcessService/SecretDelete"
+	ProcessService_SecretRotate_FullMethodName   = "/pm0.ProcessService/SecretRotate"
+	ProcessService_Clone_FullMethodName          = "/pm0.ProcessService/Clone"
+	ProcessService_TemplateSet_FullMethodName    = "/pm0.ProcessService/TemplateSet"
+	ProcessService_TemplateList_FullMethodName   = "/pm0.ProcessService/TemplateList"
+	ProcessService_TemplateDelete_FullMethodName = "/pm0.ProcessService/TemplateDelete"
+	ProcessService_StartTemplate_FullMethodName  = "/pm0.ProcessService/StartTemplate"
+	ProcessService_Wait_FullMethodName           = "/pm0.ProcessService/Wait"
+	ProcessService_Send_FullMethodName           = "/pm0.ProcessService/Send"
+	ProcessService_Attach_FullMethodName         = "/pm0.ProcessService/Attach"
+	ProcessService_Exec_FullMethodName           = "/pm0.ProcessService/Exec"
+	ProcessService_Signal_FullMethodName         = "/pm0.ProcessService/Signal"
+	ProcessService_SignalAll_FullMethodName      = "/pm0.ProcessService/SignalAll"
 )
 
 // ProcessServiceClient is the client API for ProcessService service.
@@ -39,6 +59,7 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProcessServiceClient interface {
 	Start(ctx context.Context, in *StartRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	StartExisting(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error)
 	List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListResponse, error)
 	Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error)
 	StopAll(ctx context.Context, in *ExceptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error)
@@ -50,6 +71,25 @@ type ProcessServiceClient interface {
 	Show(ctx context.Context, in *ShowRequest, opts ...grpc.CallOption) (*ShowResponse, error)
 	LogsClear(ctx context.Context, in *LogsClearRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	Update(ctx context.Context, in *UpdateRequst, opts ...grpc.CallOption) (*UpdateResponse, error)
+	Config(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigResponse, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error)
+	SecretSet(ctx context.Context, in *SecretSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SecretGet(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*SecretResponse, error)
+	SecretList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SecretListResponse, error)
+	SecretDelete(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SecretRotate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SecretRotateResponse, error)
+	Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	TemplateSet(ctx context.Context, in *TemplateSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TemplateList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TemplateListResponse, error)
+	TemplateDelete(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	StartTemplate(ctx context.Context, in *StartTemplateRequest, opts ...grpc.CallOption) (*StartResponse, error)
+	Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error)
+	Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	Attach(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AttachRequest, AttachResponse], error)
+	Exec(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecRequest, ExecResponse], error)
+	Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error)
+	SignalAll(ctx context.Context, in *SignalAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error)
 }
 
 type processServiceClient struct {
@@ -70,6 +110,25 @@ func (c *processServiceClient) Start(ctx context.Context, in *StartRequest, opts
 	return out, nil
 }
 
+func (c *processServiceClient) StartExisting(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[0], ProcessService_StartExisting_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[StopRequest, StopResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_StartExistingClient = grpc.ServerStreamingClient[StopResponse]
+
 func (c *processServiceClient) List(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListResponse)
@@ -82,7 +141,7 @@ func (c *processServiceClient) List(ctx context.Context, in *emptypb.Empty, opts
 
 func (c *processServiceClient) Stop(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[0], ProcessService_Stop_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[1], ProcessService_Stop_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +160,7 @@ type ProcessService_StopClient = grpc.ServerStreamingClient[StopResponse]
 
 func (c *processServiceClient) StopAll(ctx context.Context, in *ExceptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[1], ProcessService_StopAll_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[2], ProcessService_StopAll_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +179,7 @@ type ProcessService_StopAllClient = grpc.ServerStreamingClient[StopResponse]
 
 func (c *processServiceClient) Restart(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[2], ProcessService_Restart_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[3], ProcessService_Restart_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -139,7 +198,7 @@ type ProcessService_RestartClient = grpc.ServerStreamingClient[StopResponse]
 
 func (c *processServiceClient) RestartAll(ctx context.Context, in *ExceptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[3], ProcessService_RestartAll_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[4], ProcessService_RestartAll_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +217,7 @@ type ProcessService_RestartAllClient = grpc.ServerStreamingClient[StopResponse]
 
 func (c *processServiceClient) Logs(ctx context.Context, in *LogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[LogsResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[4], ProcessService_Logs_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[5], ProcessService_Logs_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -177,7 +236,7 @@ type ProcessService_LogsClient = grpc.ServerStreamingClient[LogsResponse]
 
 func (c *processServiceClient) Delete(ctx context.Context, in *StopRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[5], ProcessService_Delete_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[6], ProcessService_Delete_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -196,7 +255,7 @@ type ProcessService_DeleteClient = grpc.ServerStreamingClient[StopResponse]
 
 func (c *processServiceClient) DeleteAll(ctx context.Context, in *ExceptRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[6], ProcessService_DeleteAll_FullMethodName, cOpts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[7], ProcessService_DeleteAll_FullMethodName, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -243,11 +302,235 @@ func (c *processServiceClient) Update(ctx context.Context, in *UpdateRequst, opt
 	return out, nil
 }
 
+func (c *processServiceClient) Config(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ConfigResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfigResponse)
+	err := c.cc.Invoke(ctx, ProcessService_Config_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, ProcessService_History_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) Events(ctx context.Context, in *EventsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Event], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[8], ProcessService_Events_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[EventsRequest, Event]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_EventsClient = grpc.ServerStreamingClient[Event]
+
+func (c *processServiceClient) SecretSet(ctx context.Context, in *SecretSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProcessService_SecretSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) SecretGet(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*SecretResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretResponse)
+	err := c.cc.Invoke(ctx, ProcessService_SecretGet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) SecretList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SecretListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretListResponse)
+	err := c.cc.Invoke(ctx, ProcessService_SecretList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) SecretDelete(ctx context.Context, in *SecretRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProcessService_SecretDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) SecretRotate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SecretRotateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SecretRotateResponse)
+	err := c.cc.Invoke(ctx, ProcessService_SecretRotate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) Clone(ctx context.Context, in *CloneRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartResponse)
+	err := c.cc.Invoke(ctx, ProcessService_Clone_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) TemplateSet(ctx context.Context, in *TemplateSetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProcessService_TemplateSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) TemplateList(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TemplateListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TemplateListResponse)
+	err := c.cc.Invoke(ctx, ProcessService_TemplateList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) TemplateDelete(ctx context.Context, in *TemplateRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProcessService_TemplateDelete_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) StartTemplate(ctx context.Context, in *StartTemplateRequest, opts ...grpc.CallOption) (*StartResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(StartResponse)
+	err := c.cc.Invoke(ctx, ProcessService_StartTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) Wait(ctx context.Context, in *WaitRequest, opts ...grpc.CallOption) (*WaitResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WaitResponse)
+	err := c.cc.Invoke(ctx, ProcessService_Wait_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) Send(ctx context.Context, in *SendRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProcessService_Send_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *processServiceClient) Attach(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[AttachRequest, AttachResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[9], ProcessService_Attach_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AttachRequest, AttachResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_AttachClient = grpc.BidiStreamingClient[AttachRequest, AttachResponse]
+
+func (c *processServiceClient) Exec(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ExecRequest, ExecResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[10], ProcessService_Exec_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExecRequest, ExecResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_ExecClient = grpc.BidiStreamingClient[ExecRequest, ExecResponse]
+
+func (c *processServiceClient) Signal(ctx context.Context, in *SignalRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[11], ProcessService_Signal_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SignalRequest, StopResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_SignalClient = grpc.ServerStreamingClient[StopResponse]
+
+func (c *processServiceClient) SignalAll(ctx context.Context, in *SignalAllRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[StopResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ProcessService_ServiceDesc.Streams[12], ProcessService_SignalAll_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SignalAllRequest, StopResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_SignalAllClient = grpc.ServerStreamingClient[StopResponse]
+
 // ProcessServiceServer is the server API for ProcessService service.
 // All implementations must embed UnimplementedProcessServiceServer
 // for forward compatibility.
 type ProcessServiceServer interface {
 	Start(context.Context, *StartRequest) (*StartResponse, error)
+	StartExisting(*StopRequest, grpc.ServerStreamingServer[StopResponse]) error
 	List(context.Context, *emptypb.Empty) (*ListResponse, error)
 	Stop(*StopRequest, grpc.ServerStreamingServer[StopResponse]) error
 	StopAll(*ExceptRequest, grpc.ServerStreamingServer[StopResponse]) error
@@ -259,6 +542,25 @@ type ProcessServiceServer interface {
 	Show(context.Context, *ShowRequest) (*ShowResponse, error)
 	LogsClear(context.Context, *LogsClearRequest) (*emptypb.Empty, error)
 	Update(context.Context, *UpdateRequst) (*UpdateResponse, error)
+	Config(context.Context, *emptypb.Empty) (*ConfigResponse, error)
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	Events(*EventsRequest, grpc.ServerStreamingServer[Event]) error
+	SecretSet(context.Context, *SecretSetRequest) (*emptypb.Empty, error)
+	SecretGet(context.Context, *SecretRequest) (*SecretResponse, error)
+	SecretList(context.Context, *emptypb.Empty) (*SecretListResponse, error)
+	SecretDelete(context.Context, *SecretRequest) (*emptypb.Empty, error)
+	SecretRotate(context.Context, *emptypb.Empty) (*SecretRotateResponse, error)
+	Clone(context.Context, *CloneRequest) (*StartResponse, error)
+	TemplateSet(context.Context, *TemplateSetRequest) (*emptypb.Empty, error)
+	TemplateList(context.Context, *emptypb.Empty) (*TemplateListResponse, error)
+	TemplateDelete(context.Context, *TemplateRequest) (*emptypb.Empty, error)
+	StartTemplate(context.Context, *StartTemplateRequest) (*StartResponse, error)
+	Wait(context.Context, *WaitRequest) (*WaitResponse, error)
+	Send(context.Context, *SendRequest) (*emptypb.Empty, error)
+	Attach(grpc.BidiStreamingServer[AttachRequest, AttachResponse]) error
+	Exec(grpc.BidiStreamingServer[ExecRequest, ExecResponse]) error
+	Signal(*SignalRequest, grpc.ServerStreamingServer[StopResponse]) error
+	SignalAll(*SignalAllRequest, grpc.ServerStreamingServer[StopResponse]) error
 	mustEmbedUnimplementedProcessServiceServer()
 }
 
@@ -272,6 +574,9 @@ type UnimplementedProcessServiceServer struct{}
 func (UnimplementedProcessServiceServer) Start(context.Context, *StartRequest) (*StartResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Start not implemented")
 }
+func (UnimplementedProcessServiceServer) StartExisting(*StopRequest, grpc.ServerStreamingServer[StopResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method StartExisting not implemented")
+}
 func (UnimplementedProcessServiceServer) List(context.Context, *emptypb.Empty) (*ListResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
@@ -305,6 +610,63 @@ func (UnimplementedProcessServiceServer) LogsClear(context.Context, *LogsClearRe
 func (UnimplementedProcessServiceServer) Update(context.Context, *UpdateRequst) (*UpdateResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedProcessServiceServer) Config(context.Context, *emptypb.Empty) (*ConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Config not implemented")
+}
+func (UnimplementedProcessServiceServer) History(context.Context, *HistoryRequest) (*HistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method History not implemented")
+}
+func (UnimplementedProcessServiceServer) Events(*EventsRequest, grpc.ServerStreamingServer[Event]) error {
+	return status.Errorf(codes.Unimplemented, "method Events not implemented")
+}
+func (UnimplementedProcessServiceServer) SecretSet(context.Context, *SecretSetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretSet not implemented")
+}
+func (UnimplementedProcessServiceServer) SecretGet(context.Context, *SecretRequest) (*SecretResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretGet not implemented")
+}
+func (UnimplementedProcessServiceServer) SecretList(context.Context, *emptypb.Empty) (*SecretListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretList not implemented")
+}
+func (UnimplementedProcessServiceServer) SecretDelete(context.Context, *SecretRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretDelete not implemented")
+}
+func (UnimplementedProcessServiceServer) SecretRotate(context.Context, *emptypb.Empty) (*SecretRotateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SecretRotate not implemented")
+}
+func (UnimplementedProcessServiceServer) Clone(context.Context, *CloneRequest) (*StartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Clone not implemented")
+}
+func (UnimplementedProcessServiceServer) TemplateSet(context.Context, *TemplateSetRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TemplateSet not implemented")
+}
+func (UnimplementedProcessServiceServer) TemplateList(context.Context, *emptypb.Empty) (*TemplateListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TemplateList not implemented")
+}
+func (UnimplementedProcessServiceServer) TemplateDelete(context.Context, *TemplateRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TemplateDelete not implemented")
+}
+func (UnimplementedProcessServiceServer) StartTemplate(context.Context, *StartTemplateRequest) (*StartResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTemplate not implemented")
+}
+func (UnimplementedProcessServiceServer) Wait(context.Context, *WaitRequest) (*WaitResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Wait not implemented")
+}
+func (UnimplementedProcessServiceServer) Send(context.Context, *SendRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Send not implemented")
+}
+func (UnimplementedProcessServiceServer) Attach(grpc.BidiStreamingServer[AttachRequest, AttachResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Attach not implemented")
+}
+func (UnimplementedProcessServiceServer) Exec(grpc.BidiStreamingServer[ExecRequest, ExecResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedProcessServiceServer) Signal(*SignalRequest, grpc.ServerStreamingServer[StopResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method Signal not implemented")
+}
+func (UnimplementedProcessServiceServer) SignalAll(*SignalAllRequest, grpc.ServerStreamingServer[StopResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method SignalAll not implemented")
+}
 func (UnimplementedProcessServiceServer) mustEmbedUnimplementedProcessServiceServer() {}
 func (UnimplementedProcessServiceServer) testEmbeddedByValue()                        {}
 
@@ -344,6 +706,17 @@ func _ProcessService_Start_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProcessService_StartExisting_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StopRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProcessServiceServer).StartExisting(m, &grpc.GenericServerStream[StopRequest, StopResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_StartExistingServer = grpc.ServerStreamingServer[StopResponse]
+
 func _ProcessService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -493,6 +866,305 @@ func _ProcessService_Update_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProcessService_Config_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).Config(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_Config_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).Config(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_History_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_Events_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(EventsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProcessServiceServer).Events(m, &grpc.GenericServerStream[EventsRequest, Event]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_EventsServer = grpc.ServerStreamingServer[Event]
+
+func _ProcessService_SecretSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).SecretSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_SecretSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).SecretSet(ctx, req.(*SecretSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_SecretGet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).SecretGet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_SecretGet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).SecretGet(ctx, req.(*SecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_SecretList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).SecretList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_SecretList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).SecretList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_SecretDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SecretRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).SecretDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_SecretDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).SecretDelete(ctx, req.(*SecretRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_SecretRotate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).SecretRotate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_SecretRotate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).SecretRotate(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_Clone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CloneRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).Clone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_Clone_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).Clone(ctx, req.(*CloneRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_TemplateSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).TemplateSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_TemplateSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).TemplateSet(ctx, req.(*TemplateSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_TemplateList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).TemplateList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_TemplateList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).TemplateList(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_TemplateDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).TemplateDelete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_TemplateDelete_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).TemplateDelete(ctx, req.(*TemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_StartTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).StartTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_StartTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).StartTemplate(ctx, req.(*StartTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_Wait_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WaitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).Wait(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_Wait_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).Wait(ctx, req.(*WaitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_Send_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProcessServiceServer).Send(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProcessService_Send_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProcessServiceServer).Send(ctx, req.(*SendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProcessService_Attach_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProcessServiceServer).Attach(&grpc.GenericServerStream[AttachRequest, AttachResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_AttachServer = grpc.BidiStreamingServer[AttachRequest, AttachResponse]
+
+func _ProcessService_Exec_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(ProcessServiceServer).Exec(&grpc.GenericServerStream[ExecRequest, ExecResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_ExecServer = grpc.BidiStreamingServer[ExecRequest, ExecResponse]
+
+func _ProcessService_Signal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignalRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProcessServiceServer).Signal(m, &grpc.GenericServerStream[SignalRequest, StopResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_SignalServer = grpc.ServerStreamingServer[StopResponse]
+
+func _ProcessService_SignalAll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SignalAllRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProcessServiceServer).SignalAll(m, &grpc.GenericServerStream[SignalAllRequest, StopResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ProcessService_SignalAllServer = grpc.ServerStreamingServer[StopResponse]
+
 // ProcessService_ServiceDesc is the grpc.ServiceDesc for ProcessService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -520,8 +1192,69 @@ var ProcessService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Update",
 			Handler:    _ProcessService_Update_Handler,
 		},
+		{
+			MethodName: "Config",
+			Handler:    _ProcessService_Config_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _ProcessService_History_Handler,
+		},
+		{
+			MethodName: "SecretSet",
+			Handler:    _ProcessService_SecretSet_Handler,
+		},
+		{
+			MethodName: "SecretGet",
+			Handler:    _ProcessService_SecretGet_Handler,
+		},
+		{
+			MethodName: "SecretList",
+			Handler:    _ProcessService_SecretList_Handler,
+		},
+		{
+			MethodName: "SecretDelete",
+			Handler:    _ProcessService_SecretDelete_Handler,
+		},
+		{
+			MethodName: "SecretRotate",
+			Handler:    _ProcessService_SecretRotate_Handler,
+		},
+		{
+			MethodName: "Clone",
+			Handler:    _ProcessService_Clone_Handler,
+		},
+		{
+			MethodName: "TemplateSet",
+			Handler:    _ProcessService_TemplateSet_Handler,
+		},
+		{
+			MethodName: "TemplateList",
+			Handler:    _ProcessService_TemplateList_Handler,
+		},
+		{
+			MethodName: "TemplateDelete",
+			Handler:    _ProcessService_TemplateDelete_Handler,
+		},
+		{
+			MethodName: "StartTemplate",
+			Handler:    _ProcessService_StartTemplate_Handler,
+		},
+		{
+			MethodName: "Wait",
+			Handler:    _ProcessService_Wait_Handler,
+		},
+		{
+			MethodName: "Send",
+			Handler:    _ProcessService_Send_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StartExisting",
+			Handler:       _ProcessService_StartExisting_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "Stop",
 			Handler:       _ProcessService_Stop_Handler,
@@ -557,6 +1290,33 @@ var ProcessService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _ProcessService_DeleteAll_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Events",
+			Handler:       _ProcessService_Events_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Attach",
+			Handler:       _ProcessService_Attach_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Exec",
+			Handler:       _ProcessService_Exec_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Signal",
+			Handler:       _ProcessService_Signal_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "SignalAll",
+			Handler:       _ProcessService_SignalAll_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/pm0.proto",
 }
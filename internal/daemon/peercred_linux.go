@@ -0,0 +1,33 @@
+package daemon
+
+import (
+	"net"
+	"syscall"
+)
+
+func readPeerCaller(conn *net.UnixConn) (Caller, error) {
+	rawConn, err := conn.SyscallConn()
+
+	if err != nil {
+		return Caller{}, err
+	}
+
+	var (
+		ucred    *syscall.Ucred
+		ucredErr error
+	)
+
+	err = rawConn.Control(func(fd uintptr) {
+		ucred, ucredErr = syscall.GetsockoptUcred(int(fd), syscall.SOL_SOCKET, syscall.SO_PEERCRED)
+	})
+
+	if err != nil {
+		return Caller{}, err
+	}
+
+	if ucredErr != nil {
+		return Caller{}, ucredErr
+	}
+
+	return Caller{UID: ucred.Uid, GID: ucred.Gid, PID: ucred.Pid}, nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package daemon
+
+import (
+	"errors"
+	"net"
+)
+
+func readPeerCaller(conn *net.UnixConn) (Caller, error) {
+	return Caller{}, errors.New("peer credentials are only supported on linux")
+}
@@ -0,0 +1,43 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// processStartTime reads the process start time in clock ticks since boot,
+// which tells the process apart from a later one that reused its pid
+func processStartTime(pid int) (uint64, error) {
+	stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+
+	if err != nil {
+		return 0, err
+	}
+
+	commEnd := strings.LastIndexByte(string(stat), ')')
+
+	if commEnd == -1 {
+		return 0, fmt.Errorf("malformed /proc/%d/stat", pid)
+	}
+
+	// fields after the command name start from the 3rd one, start time is the 22nd
+	fields := strings.Fields(string(stat[commEnd+1:]))
+
+	if len(fields) < 20 {
+		return 0, fmt.Errorf("malformed /proc/%d/stat", pid)
+	}
+
+	return strconv.ParseUint(fields[19], 10, 64)
+}
+
+func processAlive(pid int, startTime uint64) bool {
+	if err := syscall.Kill(pid, 0); err != nil && err != syscall.EPERM {
+		return false
+	}
+
+	currentStartTime, err := processStartTime(pid)
+	return err == nil && currentStartTime == startTime
+}
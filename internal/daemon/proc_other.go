@@ -0,0 +1,13 @@
+//go:build !linux
+
+package daemon
+
+import "errors"
+
+func processStartTime(pid int) (uint64, error) {
+	return 0, errors.New("process start time is only supported on linux")
+}
+
+func processAlive(pid int, startTime uint64) bool {
+	return false
+}
@@ -0,0 +1,180 @@
+package daemon
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/creack/pty"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	defaultPTYRows = 24
+	defaultPTYCols = 80
+
+	// ptyDrainTimeout limits the wait for the output left in the pseudo-terminal
+	// after the unit exits, its children may still hold the terminal open
+	ptyDrainTimeout = time.Second
+)
+
+// openUnitPTY allocates a pseudo-terminal and makes it the stdin, stdout,
+// stderr and controlling terminal of the command. The master is used as the
+// unit stdin, the terminal is closed by the caller once the process is started
+func openUnitPTY(command *exec.Cmd) (master *os.File, terminal *os.File, err error) {
+	ptmx, terminal, err := pty.Open()
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// pty.Open leaves the master in blocking mode, a non-blocking copy goes
+	// through the poller so the stdin write deadlines work and Close interrupts reads
+	fd, err := unix.Dup(int(ptmx.Fd()))
+	ptmx.Close()
+
+	if err != nil {
+		terminal.Close()
+		return nil, nil, err
+	}
+
+	if err := unix.SetNonblock(fd, true); err != nil {
+		unix.Close(fd)
+		terminal.Close()
+		return nil, nil, err
+	}
+
+	master = os.NewFile(uintptr(fd), ptmx.Name())
+
+	if err := setPTYSize(master, defaultPTYRows, defaultPTYCols); err != nil {
+		master.Close()
+		terminal.Close()
+		return nil, nil, err
+	}
+
+	command.Stdin = terminal
+	command.Stdout = terminal
+	command.Stderr = terminal
+
+	// the new session makes the terminal controlling, its process group is
+	// still the unit pid, so the unit is stopped the same way
+	command.SysProcAttr.Setpgid = false
+	command.SysProcAttr.Setsid = true
+	command.SysProcAttr.Setctty = true
+	command.SysProcAttr.Ctty = 0
+
+	return master, terminal, nil
+}
+
+// setPTYSize sets the window size of the pseudo-terminal without switching
+// the master to blocking mode like File.Fd does
+func setPTYSize(master *os.File, rows uint16, cols uint16) error {
+	conn, err := master.SyscallConn()
+
+	if err != nil {
+		return err
+	}
+
+	var sizeErr error
+
+	err = conn.Control(func(fd uintptr) {
+		sizeErr = unix.IoctlSetWinsize(int(fd), unix.TIOCSWINSZ, &unix.Winsize{Row: rows, Col: cols})
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return sizeErr
+}
+
+// copyPTYOutput copies the output of the process from the pseudo-terminal
+// to the log file or the exec stream until the terminal is closed
+func copyPTYOutput(master *os.File, output io.Writer, stripANSI bool) <-chan struct{} {
+	done := make(chan struct{})
+
+	if stripANSI {
+		output = &ansiStripper{w: output}
+	}
+
+	go func() {
+		defer close(done)
+		io.Copy(output, master)
+	}()
+
+	return done
+}
+
+// Resize sets the window size of the unit pseudo-terminal
+func (u *Unit) Resize(rows uint32, cols uint32) error {
+	u.stdinMu.Lock()
+	defer u.stdinMu.Unlock()
+
+	if u.stdin == nil {
+		return errUnitStdinClosed
+	}
+
+	return setPTYSize(u.stdin, uint16(rows), uint16(cols))
+}
+
+const (
+	ansiText = iota
+	ansiEscape
+	ansiCSI
+	ansiOSC
+	ansiOSCEscape
+)
+
+// ansiStripper removes the ANSI escape sequences from the written output.
+// It keeps its state between writes, a sequence may be split across reads
+type ansiStripper struct {
+	w     io.Writer
+	state int
+}
+
+func (s *ansiStripper) Write(p []byte) (int, error) {
+	output := make([]byte, 0, len(p))
+
+	for _, char := range p {
+		switch s.state {
+		case ansiText:
+			if char == 0x1b {
+				s.state = ansiEscape
+				continue
+			}
+
+			output = append(output, char)
+		case ansiEscape:
+			switch {
+			case char == '[':
+				s.state = ansiCSI
+			case char == ']':
+				s.state = ansiOSC
+			case char >= 0x20 && char <= 0x2f:
+				// intermediate bytes like the charset selection ESC ( B
+			default:
+				s.state = ansiText
+			}
+		case ansiCSI:
+			if char >= 0x40 && char <= 0x7e {
+				s.state = ansiText
+			}
+		case ansiOSC:
+			switch char {
+			case 0x07:
+				s.state = ansiText
+			case 0x1b:
+				s.state = ansiOSCEscape
+			}
+		case ansiOSCEscape:
+			s.state = ansiText
+		}
+	}
+
+	if _, err := s.w.Write(output); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
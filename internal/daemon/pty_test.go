@@ -0,0 +1,49 @@
+package daemon
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestANSIStripper(t *testing.T) {
+	tests := []struct {
+		name   string
+		writes []string
+		output string
+	}{
+		{"plain text", []string{"hello\r\n"}, "hello\r\n"},
+		{"colors", []string{"\x1b[1;31mred\x1b[0m text"}, "red text"},
+		{"cursor movement", []string{"a\x1b[2Kb\x1b[10;20Hc"}, "abc"},
+		{"private mode", []string{"\x1b[?25lhidden cursor\x1b[?25h"}, "hidden cursor"},
+		{"charset selection", []string{"\x1b(Btext"}, "text"},
+		{"two byte escape", []string{"\x1b7saved\x1b8"}, "saved"},
+		{"osc title with bel", []string{"\x1b]0;title\x07prompt$ "}, "prompt$ "},
+		{"osc title with st", []string{"\x1b]2;title\x1b\\prompt$ "}, "prompt$ "},
+		{"sequence split across writes", []string{"a\x1b", "[3", "1mb\x1b[", "0m"}, "ab"},
+		{"osc split across writes", []string{"\x1b]0;ti", "tle\x1b", "\\c"}, "c"},
+		{"empty writes", []string{"", "a", ""}, "a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var output bytes.Buffer
+			stripper := &ansiStripper{w: &output}
+
+			for _, write := range test.writes {
+				n, err := stripper.Write([]byte(write))
+
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if n != len(write) {
+					t.Fatalf("wrote %d bytes of %d", n, len(write))
+				}
+			}
+
+			if output.String() != test.output {
+				t.Fatalf("output %q, expected %q", output.String(), test.output)
+			}
+		})
+	}
+}
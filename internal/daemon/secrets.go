@@ -0,0 +1,454 @@
+package daemon
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	DefaultSecretKeyFilename = "pm0_secret.key"
+	secretKeySize            = 32
+	secretKeyFilePerm        = 0600
+	secretReferencePrefix    = "@secret:"
+)
+
+var (
+	secretNameRegexp  = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,128}$`)
+	errSecretNotFound = errors.New("not found")
+)
+
+// SecretModel is a secret encrypted with AES-GCM. Secrets are namespaced by
+// their owner, the owner and the name are authenticated with the value, so
+// a stored value can't be moved to another secret
+type SecretModel struct {
+	ID        string `storm:"id"`
+	Name      string
+	OwnerUID  uint32 `storm:"index"`
+	KeyID     string
+	Value     []byte
+	UpdatedAt time.Time
+}
+
+func (m *SecretModel) PB() *pb.Secret {
+	return &pb.Secret{
+		Name:      m.Name,
+		OwnerUid:  m.OwnerUID,
+		UpdatedAt: m.UpdatedAt.UnixMilli(),
+	}
+}
+
+// secretKeyring holds the keys of the key file, the first one encrypts new
+// values. The previous key is only kept while the secrets are re-encrypted
+// by a rotation, so an interrupted rotation doesn't lose them
+type secretKeyring struct {
+	keys [][]byte
+}
+
+func secretKeyID(key []byte) string {
+	sum := sha256.Sum256(key)
+	return hex.EncodeToString(sum[:8])
+}
+
+// loadSecretKeyring reads the hex encoded keys of the key file. The file is
+// created with a new key if it doesn't exist and create is set
+func loadSecretKeyring(keyFilepath string, create bool) (*secretKeyring, error) {
+	keyFile, err := os.Open(keyFilepath)
+
+	if errors.Is(err, fs.ErrNotExist) && create {
+		key := make([]byte, secretKeySize)
+
+		if _, err := rand.Read(key); err != nil {
+			return nil, err
+		}
+
+		keyring := &secretKeyring{keys: [][]byte{key}}
+		return keyring, keyring.write(keyFilepath)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer keyFile.Close()
+
+	keyFileInfo, err := keyFile.Stat()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if keyFileInfo.Mode().Perm()&0077 != 0 {
+		return nil, fmt.Errorf("secret key file %s should only be accessible by its owner", keyFilepath)
+	}
+
+	keyring := &secretKeyring{}
+	scanner := bufio.NewScanner(keyFile)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if len(line) == 0 {
+			continue
+		}
+
+		key, err := hex.DecodeString(line)
+
+		if err != nil || len(key) != secretKeySize {
+			return nil, fmt.Errorf("secret key file %s has an invalid key", keyFilepath)
+		}
+
+		keyring.keys = append(keyring.keys, key)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(keyring.keys) == 0 {
+		return nil, fmt.Errorf("secret key file %s is empty", keyFilepath)
+	}
+
+	return keyring, nil
+}
+
+// write replaces the key file atomically
+func (k *secretKeyring) write(keyFilepath string) error {
+	var content bytes.Buffer
+
+	for _, key := range k.keys {
+		content.WriteString(hex.EncodeToString(key) + "\n")
+	}
+
+	tmpFilepath := keyFilepath + ".tmp"
+	os.Remove(tmpFilepath)
+
+	tmpFile, err := os.OpenFile(tmpFilepath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, secretKeyFilePerm)
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := tmpFile.Write(content.Bytes()); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return err
+	}
+
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpFilepath, keyFilepath)
+}
+
+func (k *secretKeyring) encrypt(secret *SecretModel, value []byte) error {
+	key := k.keys[0]
+	gcm, err := newSecretCipher(key)
+
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	secret.KeyID = secretKeyID(key)
+	secret.Value = gcm.Seal(nonce, nonce, value, []byte(secret.ID))
+	return nil
+}
+
+func (k *secretKeyring) decrypt(secret *SecretModel) ([]byte, error) {
+	for _, key := range k.keys {
+		if secretKeyID(key) != secret.KeyID {
+			continue
+		}
+
+		gcm, err := newSecretCipher(key)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(secret.Value) < gcm.NonceSize() {
+			return nil, fmt.Errorf("secret %s is corrupted", secret.Name)
+		}
+
+		nonce, ciphertext := secret.Value[:gcm.NonceSize()], secret.Value[gcm.NonceSize():]
+		value, err := gcm.Open(nil, nonce, ciphertext, []byte(secret.ID))
+
+		if err != nil {
+			return nil, fmt.Errorf("secret %s can't be decrypted: %w", secret.Name, err)
+		}
+
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("secret %s is encrypted with a key missing from the key file", secret.Name)
+}
+
+func newSecretCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func (s *DaemonServer) secretKeyFilepath() string {
+	config := s.getConfig()
+	return config.SecretKeyFilepath()
+}
+
+// getSecretValue decrypts the secret of the owner, it's used to resolve the
+// @secret: references of the unit env on spawn
+func (s *DaemonServer) getSecretValue(ownerUID uint32, name string) ([]byte, error) {
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	var secret SecretModel
+
+	db := s.Options.DBFactory()
+	err := db.One("ID", ownedID(ownerUID, name), &secret)
+	db.Close()
+
+	if err == storm.ErrNotFound {
+		return nil, fmt.Errorf("secret %s %w", name, errSecretNotFound)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	keyring, err := loadSecretKeyring(s.secretKeyFilepath(), false)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keyring.decrypt(&secret)
+}
+
+func (s *DaemonServer) SecretSet(
+	ctx context.Context,
+	request *pb.SecretSetRequest,
+) (*emptypb.Empty, error) {
+	if !secretNameRegexp.MatchString(request.Name) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"secret name %q should only have letters, digits, _, . and -",
+			request.Name,
+		)
+	}
+
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	keyring, err := loadSecretKeyring(s.secretKeyFilepath(), true)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ownerUID := ownerFromContext(ctx)
+	secret := SecretModel{
+		ID:        ownedID(ownerUID, request.Name),
+		Name:      request.Name,
+		OwnerUID:  ownerUID,
+		UpdatedAt: time.Now(),
+	}
+
+	if err := keyring.encrypt(&secret, []byte(request.Value)); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	if err := db.Save(&secret); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return emptyResponse, nil
+}
+
+func (s *DaemonServer) SecretGet(
+	ctx context.Context,
+	request *pb.SecretRequest,
+) (*pb.SecretResponse, error) {
+	value, err := s.getSecretValue(ownerFromContext(ctx), request.Name)
+
+	if errors.Is(err, errSecretNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.SecretResponse{Name: request.Name, Value: string(value)}, nil
+}
+
+// SecretList returns the secrets of the caller without their values. Root
+// sees the secrets of every user
+func (s *DaemonServer) SecretList(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.SecretListResponse, error) {
+	var secrets []SecretModel
+
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	query := db.Select().OrderBy("ID")
+
+	if ownerUID := ownerFromContext(ctx); ownerUID != 0 {
+		query = db.Select(q.Eq("OwnerUID", ownerUID)).OrderBy("ID")
+	}
+
+	if err := query.Find(&secrets); err != nil && err != storm.ErrNotFound {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := pb.SecretListResponse{Secrets: make([]*pb.Secret, len(secrets))}
+
+	for i := range secrets {
+		response.Secrets[i] = secrets[i].PB()
+	}
+
+	return &response, nil
+}
+
+func (s *DaemonServer) SecretDelete(
+	ctx context.Context,
+	request *pb.SecretRequest,
+) (*emptypb.Empty, error) {
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	err := db.DeleteStruct(&SecretModel{ID: ownedID(ownerFromContext(ctx), request.Name)})
+
+	if err == storm.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "secret %s not found", request.Name)
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return emptyResponse, nil
+}
+
+// SecretRotate re-encrypts every secret with a new key. The new key is added
+// to the key file before the secrets are re-encrypted and the old one is
+// removed after, so the secrets stay readable if the rotation is interrupted
+func (s *DaemonServer) SecretRotate(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.SecretRotateResponse, error) {
+	if !callerFromContext(ctx).IsAdmin() {
+		return nil, status.Error(codes.PermissionDenied, "only root can rotate the secret key")
+	}
+
+	s.secretsMu.Lock()
+	defer s.secretsMu.Unlock()
+
+	keyFilepath := s.secretKeyFilepath()
+	keyring, err := loadSecretKeyring(keyFilepath, true)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	key := make([]byte, secretKeySize)
+
+	if _, err := rand.Read(key); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	keyring.keys = append([][]byte{key}, keyring.keys...)
+
+	if err := keyring.write(keyFilepath); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	db := s.Options.DBFactory()
+	tx, err := db.Begin(true)
+
+	if err != nil {
+		db.Close()
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	var secrets []SecretModel
+
+	if err := tx.All(&secrets); err != nil {
+		tx.Rollback()
+		db.Close()
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	for i := range secrets {
+		value, err := keyring.decrypt(&secrets[i])
+
+		if err == nil {
+			err = keyring.encrypt(&secrets[i], value)
+		}
+
+		if err == nil {
+			err = tx.Save(&secrets[i])
+		}
+
+		if err != nil {
+			tx.Rollback()
+			db.Close()
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	err = tx.Commit()
+	db.Close()
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	keyring.keys = keyring.keys[:1]
+
+	if err := keyring.write(keyFilepath); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &pb.SecretRotateResponse{Secrets: uint32(len(secrets))}, nil
+}
@@ -0,0 +1,178 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestSecretsAfterKeyRotation(t *testing.T) {
+	s := newTestServer(t)
+	userCtx := ContextWithCaller(context.Background(), &Caller{UID: 1000})
+
+	secrets := []struct {
+		ctx   context.Context
+		name  string
+		value string
+	}{
+		{testContext(), "db-password", "root secret"},
+		{userCtx, "db-password", "user secret"},
+		{userCtx, "api.token", "multi\nline value"},
+		{userCtx, "empty", ""},
+	}
+
+	for _, secret := range secrets {
+		_, err := s.SecretSet(secret.ctx, &pb.SecretSetRequest{Name: secret.name, Value: secret.value})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keyFilepath := s.secretKeyFilepath()
+	keyring, err := loadSecretKeyring(keyFilepath, false)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previousKeyID := secretKeyID(keyring.keys[0])
+
+	for rotation := 1; rotation <= 2; rotation++ {
+		response, err := s.SecretRotate(testContext(), &emptypb.Empty{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if response.Secrets != uint32(len(secrets)) {
+			t.Fatalf("rotation %d re-encrypted %d secrets, expected %d", rotation, response.Secrets, len(secrets))
+		}
+
+		keyring, err := loadSecretKeyring(keyFilepath, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(keyring.keys) != 1 || secretKeyID(keyring.keys[0]) == previousKeyID {
+			t.Fatalf("rotation %d left %d keys, expected a single new key", rotation, len(keyring.keys))
+		}
+
+		previousKeyID = secretKeyID(keyring.keys[0])
+
+		for _, secret := range secrets {
+			response, err := s.SecretGet(secret.ctx, &pb.SecretRequest{Name: secret.name})
+
+			if err != nil {
+				t.Fatalf("rotation %d: %s: %v", rotation, secret.name, err)
+			}
+
+			if response.Value != secret.value {
+				t.Fatalf("rotation %d: %s is %q, expected %q", rotation, secret.name, response.Value, secret.value)
+			}
+		}
+	}
+
+	if _, err := s.SecretRotate(userCtx, &emptypb.Empty{}); err == nil {
+		t.Fatal("a user rotated the secret key")
+	}
+}
+
+func TestSecretKeyringDecrypt(t *testing.T) {
+	oldKeyring := &secretKeyring{keys: [][]byte{testSecretKey(1)}}
+	newKeyring := &secretKeyring{keys: [][]byte{testSecretKey(2)}}
+	rotatingKeyring := &secretKeyring{keys: [][]byte{testSecretKey(2), testSecretKey(1)}}
+
+	tests := []struct {
+		name    string
+		keyring *secretKeyring // decrypts the secret encrypted with the old key
+		change  func(secret *SecretModel)
+		err     string
+	}{
+		{name: "same key", keyring: oldKeyring},
+		{name: "previous key kept during a rotation", keyring: rotatingKeyring},
+		{name: "previous key removed", keyring: newKeyring, err: "missing from the key file"},
+		{
+			name:    "value moved to another secret",
+			keyring: oldKeyring,
+			change: func(secret *SecretModel) {
+				secret.ID = ownedID(1000, secret.Name)
+			},
+			err: "can't be decrypted",
+		},
+		{
+			name:    "tampered value",
+			keyring: oldKeyring,
+			change: func(secret *SecretModel) {
+				secret.Value[len(secret.Value)-1] ^= 1
+			},
+			err: "can't be decrypted",
+		},
+		{
+			name:    "truncated value",
+			keyring: oldKeyring,
+			change: func(secret *SecretModel) {
+				secret.Value = secret.Value[:4]
+			},
+			err: "is corrupted",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secret := SecretModel{ID: ownedID(0, "db-password"), Name: "db-password"}
+
+			if err := oldKeyring.encrypt(&secret, []byte("value")); err != nil {
+				t.Fatal(err)
+			}
+
+			if test.change != nil {
+				test.change(&secret)
+			}
+
+			value, err := test.keyring.decrypt(&secret)
+
+			if len(test.err) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("err %v, expected %q", err, test.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(value) != "value" {
+				t.Fatalf("value %q, expected %q", value, "value")
+			}
+		})
+	}
+}
+
+// testSecretKey returns a key filled with the byte
+func testSecretKey(fill byte) []byte {
+	return []byte(strings.Repeat(string(rune(fill)), secretKeySize))
+}
+
+func TestLoadSecretKeyringRefusesSharedKeyFile(t *testing.T) {
+	keyFilepath := t.TempDir() + "/secret.key"
+
+	if _, err := loadSecretKeyring(keyFilepath, true); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chmod(keyFilepath, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := loadSecretKeyring(keyFilepath, false); err == nil {
+		t.Fatal("loaded a key file readable by other users")
+	}
+}
@@ -0,0 +1,111 @@
+package daemon
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+var labelKeyRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_./-]{0,62}$`)
+
+// UnitMatchesSelector reports whether the unit is selected by its id, by a
+// key=value label selector or by a glob pattern matching its name. Label
+// selector values can be glob patterns too
+func UnitMatchesSelector(unit *pb.Unit, selector string) bool {
+	if unitID, err := strconv.ParseUint(selector, 10, 64); err == nil {
+		return unit.Id == unitID
+	}
+
+	if key, value, ok := strings.Cut(selector, "="); ok {
+		labelValue, ok := unit.Labels[key]
+
+		if !ok {
+			return false
+		}
+
+		matched, err := path.Match(value, labelValue)
+		return err == nil && matched
+	}
+
+	matched, err := path.Match(selector, unit.Name)
+	return err == nil && matched
+}
+
+func UnitMatchesSelectors(unit *pb.Unit, selectors []string) bool {
+	if len(selectors) == 0 {
+		return true
+	}
+
+	for _, selector := range selectors {
+		if UnitMatchesSelector(unit, selector) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseLabels parses key=value labels
+func ParseLabels(labels []string) (map[string]string, error) {
+	parsed := make(map[string]string, len(labels))
+
+	for _, label := range labels {
+		key, value, ok := strings.Cut(label, "=")
+
+		if !ok {
+			return nil, fmt.Errorf("label %q should be formatted as key=value", label)
+		}
+
+		parsed[key] = value
+	}
+
+	return parsed, ValidateLabels(parsed)
+}
+
+func ValidateLabels(labels map[string]string) error {
+	for key := range labels {
+		if !labelKeyRegexp.MatchString(key) {
+			return fmt.Errorf("label key %q should only have letters, digits, _, ., / and -", key)
+		}
+	}
+
+	return nil
+}
+
+// mergeLabels returns the labels updated by the new ones, an empty value
+// removes the label
+func mergeLabels(labels map[string]string, newLabels map[string]string) map[string]string {
+	merged := maps.Clone(labels)
+
+	if merged == nil {
+		merged = make(map[string]string, len(newLabels))
+	}
+
+	for key, value := range newLabels {
+		if len(value) == 0 {
+			delete(merged, key)
+		} else {
+			merged[key] = value
+		}
+	}
+
+	return merged
+}
+
+// FormatLabels formats the labels as sorted key=value pairs
+func FormatLabels(labels map[string]string) []string {
+	formatted := make([]string, 0, len(labels))
+
+	for key, value := range labels {
+		formatted = append(formatted, key+"="+value)
+	}
+
+	slices.Sort(formatted)
+	return formatted
+}
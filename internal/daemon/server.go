@@ -3,15 +3,19 @@ package daemon
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"os"
 	"os/exec"
 	"path"
+	"reflect"
 	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/TrixiS/pm0/internal/daemon/pb"
@@ -23,15 +27,19 @@ import (
 )
 
 const (
-	logFilePerm                    = 0o660
-	unitRestartDelay time.Duration = time.Second * 5
+	logFilePerm             = 0o660
+	adoptedUnitPollInterval = time.Second
+	showHistoryEvents       = 5
+	defaultHistoryEvents    = 20
 )
 
 var emptyResponse = &emptypb.Empty{}
 
 type DaemonServerOptions struct {
-	LogsDirpath string
-	DBFactory   func() *storm.DB
+	LogsDirpath    string
+	DBFactory      func() *storm.DB
+	Config         Config
+	ConfigFilepath string
 }
 
 type DaemonServer struct {
@@ -41,13 +49,84 @@ type DaemonServer struct {
 
 	units   map[uint64]*Unit
 	unitsMu sync.RWMutex
+
+	config   Config
+	configMu sync.RWMutex
+
+	watchers     sync.WaitGroup
+	shuttingDown atomic.Bool
+
+	historyQueue  chan func(db *storm.DB)
+	historyWrites sync.WaitGroup
+	hookRuns      sync.WaitGroup
+	secretsMu     sync.Mutex
+
+	events *EventBus
 }
 
 func NewDaemonServer(options DaemonServerOptions) *DaemonServer {
-	return &DaemonServer{
-		Options: options,
-		units:   make(map[uint64]*Unit),
+	server := &DaemonServer{
+		Options:      options,
+		units:        make(map[uint64]*Unit),
+		config:       options.Config,
+		historyQueue: make(chan func(db *storm.DB), historyQueueSize),
+		events:       NewEventBus(),
+	}
+
+	go server.writeHistory()
+	return server
+}
+
+func (s *DaemonServer) SetConfig(config Config) {
+	s.configMu.Lock()
+	s.config = config
+	s.configMu.Unlock()
+
+	s.publishDaemonEvent(EventDaemonReloaded, "")
+}
+
+func (s *DaemonServer) getConfig() Config {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.config
+}
+
+func (s *DaemonServer) unitRestartPolicy(model *UnitModel) (string, time.Duration) {
+	defaults := s.getConfig().Units
+	policy := model.RestartPolicy
+	delay := model.RestartDelay
+
+	if len(policy) == 0 {
+		policy = defaults.RestartPolicy
+	}
+
+	if delay == 0 {
+		delay = time.Duration(defaults.RestartDelay)
 	}
+
+	return policy, delay
+}
+
+func (s *DaemonServer) unitStopPolicy(model *UnitModel) (syscall.Signal, time.Duration) {
+	defaults := s.getConfig().Units
+	stopSignalName := model.StopSignal
+	stopTimeout := model.StopTimeout
+
+	if len(stopSignalName) == 0 {
+		stopSignalName = defaults.StopSignal
+	}
+
+	if stopTimeout == 0 {
+		stopTimeout = time.Duration(defaults.StopTimeout)
+	}
+
+	stopSignal, err := ParseSignal(stopSignalName)
+
+	if err != nil {
+		stopSignal = syscall.SIGTERM
+	}
+
+	return stopSignal, stopTimeout
 }
 
 func (s *DaemonServer) getUnitLogFilepath(unitID uint64) string {
@@ -57,34 +136,84 @@ func (s *DaemonServer) getUnitLogFilepath(unitID uint64) string {
 func (s *DaemonServer) openUnitLogFile(unitID uint64) (*os.File, error) {
 	const logFileFlag = os.O_CREATE | os.O_RDWR | os.O_APPEND
 	logFilepath := s.getUnitLogFilepath(unitID)
-	return os.OpenFile(logFilepath, logFileFlag, logFilePerm)
+	return os.OpenFile(logFilepath, logFileFlag, os.FileMode(s.getConfig().Logs.FileMode))
 }
 
 func (s *DaemonServer) watchUnit(unit *Unit) {
-	slog.Info("unit started", "id", unit.Model.ID)
+	defer s.watchers.Done()
+
+	slog.Info("unit started", "id", unit.Model.ID, "pid", unit.Process.Pid, "adopted", unit.Adopted)
+	s.saveUnitProcess(unit)
+
+	startedEvent := &UnitEventModel{
+		Type: UnitEventStarted,
+		PID:  unit.Process.Pid,
+	}
+
+	if unit.Adopted {
+		startedEvent.Reason = "adopted after a daemon restart"
+	}
+
+	s.recordUnitEvent(unit, startedEvent)
+	s.runHooksInBackground(HookPhasePostStart, unit, nil)
+
+	if len(unit.ModelCopy().HealthCmd) > 0 {
+		go s.checkUnitHealth(unit)
+	}
 
-	unit.Command.Wait()
-	unit.LogFile.Close()
+	<-unit.Done()
+
+	if unit.LogFile != nil {
+		unit.LogFile.Close()
+	}
 
 	status := unit.Status()
 	slog.Info("unit stopped", "id", unit.Model.ID, "status", status)
+	exitEvent := s.unitExitEvent(unit, status)
+	s.recordUnitEvent(unit, exitEvent)
+	close(unit.exitHandled)
+	s.runHooksInBackground(HookPhasePostExit, unit, exitEvent)
+
+	s.deleteUnitProcess(unit)
+
+	model := unit.ModelCopy()
+	restartPolicy, restartDelay := s.unitRestartPolicy(&model)
 
-	if status != UnitStatusFailed {
+	switch {
+	case status == UnitStatusFailed && restartPolicy != RestartPolicyNever:
+	case status == UnitStatusExited && restartPolicy == RestartPolicyAlways:
+	default:
 		return
 	}
 
-	time.Sleep(unitRestartDelay)
+	s.recordUnitEvent(unit, &UnitEventModel{
+		Type:   UnitEventRestarting,
+		Reason: fmt.Sprintf("%s restart policy, restarting in %s", restartPolicy, restartDelay),
+	})
+
+	unit.restartPending.Store(true)
+	time.Sleep(restartDelay)
+
+	if !unit.restartPending.CompareAndSwap(true, false) || s.shuttingDown.Load() {
+		return
+	}
+
+	// the unit could be stopped while it was failed, by the caller of a
+	// previous daemon or before the restart was scheduled
+	if unit.ModelCopy().DesiredState == UnitDesiredStateStopped {
+		return
+	}
 
 	s.unitsMu.RLock()
 
-	if s.units[unit.Model.ID] == nil {
+	if s.units[unit.Model.ID] != unit {
 		s.unitsMu.RUnlock()
 		return
 	}
 
 	s.unitsMu.RUnlock()
 
-	_, err := s.StartUnit(unit.Model)
+	_, err := s.StartUnit(unit.ModelCopy())
 
 	if err != nil {
 		slog.Error("restart unit", "id", unit.Model.ID, "err", err)
@@ -94,72 +223,493 @@ func (s *DaemonServer) watchUnit(unit *Unit) {
 	slog.Info("restarted unit", "id", unit.Model.ID)
 }
 
+func (s *DaemonServer) saveUnitProcess(unit *Unit) {
+	startTime, err := processStartTime(unit.Process.Pid)
+
+	if err != nil {
+		slog.Warn("read unit process start time", "id", unit.Model.ID, "err", err)
+		return
+	}
+
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	err = db.Save(&UnitProcessModel{
+		UnitID:    unit.Model.ID,
+		PID:       unit.Process.Pid,
+		StartTime: startTime,
+		StartedAt: unit.StartedAt,
+	})
+
+	if err != nil {
+		slog.Error("save unit process", "id", unit.Model.ID, "err", err)
+	}
+}
+
+func (s *DaemonServer) deleteUnitProcess(unit *Unit) {
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	var processModel UnitProcessModel
+
+	if err := db.One("UnitID", unit.Model.ID, &processModel); err != nil {
+		return
+	}
+
+	if processModel.PID == unit.Process.Pid {
+		db.DeleteStruct(&processModel)
+	}
+}
+
+// getUnit returns nil if the unit doesn't exist or the caller can't access it
+func (s *DaemonServer) getUnit(caller *Caller, unitID uint64) *Unit {
+	s.unitsMu.RLock()
+	defer s.unitsMu.RUnlock()
+
+	unit := s.units[unitID]
+
+	if unit == nil || !caller.CanAccess(&unit.Model) {
+		return nil
+	}
+
+	return unit
+}
+
+func (s *DaemonServer) newUnit(model UnitModel, process *os.Process, startedAt time.Time) *Unit {
+	stopSignal, stopTimeout := s.unitStopPolicy(&model)
+	return &Unit{
+		Model:     model,
+		Process:   process,
+		StartedAt: startedAt,
+		Cancel: func() {
+			if err := syscall.Kill(-process.Pid, stopSignal); err != nil {
+				process.Signal(stopSignal)
+			}
+		},
+		stopTimeout: stopTimeout,
+		done:        make(chan struct{}),
+		exitHandled: make(chan struct{}),
+	}
+}
+
+// newStoppedUnit is used for units that shouldn't be started on daemon boot
+func newStoppedUnit(model UnitModel) *Unit {
+	unit := &Unit{
+		Model: model,
+		done:  make(chan struct{}),
+	}
+
+	unit.exit(-1, 0)
+	return unit
+}
+
+// updateUnitModel changes the model of the unit under its lock and saves the
+// fields returned by update, so the concurrent changes of the other fields are
+// kept. The database is opened around the write only: watchers of the units
+// being stopped need it too, and storm gives up waiting for the file lock
+// after a second
+func (s *DaemonServer) updateUnitModel(
+	unit *Unit,
+	update func(model *UnitModel) ([]string, error),
+) (UnitModel, error) {
+	unit.modelMu.Lock()
+	defer unit.modelMu.Unlock()
+
+	model := unit.Model
+	fields, err := update(&model)
+
+	if err != nil {
+		return unit.Model, err
+	}
+
+	db := s.Options.DBFactory()
+	err = saveUnitModelFields(db, &model, fields)
+	db.Close()
+
+	if err != nil {
+		return unit.Model, err
+	}
+
+	// only the saved fields are set, the id and the owner are read without the lock
+	unitValue := reflect.ValueOf(&unit.Model).Elem()
+	modelValue := reflect.ValueOf(model)
+
+	for _, field := range fields {
+		unitValue.FieldByName(field).Set(modelValue.FieldByName(field))
+	}
+
+	return model, nil
+}
+
+// saveUnitModelFields saves the fields of the stored model in a transaction.
+// db.Update would skip the zero values and db.Save would overwrite the others
+func saveUnitModelFields(db *storm.DB, model *UnitModel, fields []string) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	tx, err := db.Begin(true)
+
+	if err != nil {
+		return err
+	}
+
+	defer tx.Rollback()
+
+	value := reflect.ValueOf(model).Elem()
+
+	for _, field := range fields {
+		if err := tx.UpdateField(model, field, value.FieldByName(field).Interface()); err != nil {
+			return fmt.Errorf("save unit %s: %w", field, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *DaemonServer) saveUnitDesiredState(unit *Unit, desiredState UnitDesiredState) {
+	_, err := s.updateUnitModel(unit, func(model *UnitModel) ([]string, error) {
+		model.DesiredState = desiredState
+		return []string{"DesiredState"}, nil
+	})
+
+	if err != nil {
+		slog.Error("save unit desired state", "id", unit.Model.ID, "err", err)
+	}
+}
+
+// stopUnit runs the pre-stop hooks before stopping a running unit. Hook
+// failures are logged and don't prevent the stop
+func (s *DaemonServer) stopUnit(unit *Unit, reason string, by string) {
+	if unit.Status() == UnitStatusRunning {
+		model := unit.ModelCopy()
+
+		if err := s.runHooks(HookPhasePreStop, &model, unit.env, unit.PB(), nil); err != nil {
+			slog.Error("hook failed", "id", unit.Model.ID, "phase", HookPhasePreStop, "err", err)
+		}
+	}
+
+	unit.Stop(reason, by)
+}
+
 func (s *DaemonServer) StartUnit(model UnitModel) (*Unit, error) {
+	credential, err := resolveUnitCredential(&model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	pbUnit := &pb.Unit{
+		Id:            model.ID,
+		Name:          model.Name,
+		Status:        uint32(UnitStatusStopped),
+		RestartsCount: model.RestartsCount,
+		OwnerUid:      model.OwnerUID,
+		Labels:        model.Labels,
+	}
+
+	env, err := s.resolveUnitEnv(&model)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.runHooks(HookPhasePreStart, &model, env, pbUnit, nil); err != nil {
+		return nil, fmt.Errorf("pre-start hook failed: %w", err)
+	}
+
 	logFile, err := s.openUnitLogFile(model.ID)
 
 	if err != nil {
 		return nil, err
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
-	command := createUnitStartCommand(ctx, &model, logFile)
+	if credential != nil {
+		if err := logFile.Chown(int(credential.Uid), int(credential.Gid)); err != nil {
+			logFile.Close()
+			return nil, err
+		}
+	}
+
+	command := createUnitStartCommand(&model, env, logFile)
+	command.SysProcAttr = &syscall.SysProcAttr{Credential: credential, Setpgid: true}
+
+	// the stdin of tty units is the pseudo-terminal, its master is written to
+	// and the unit holds the terminal
+	var stdinReader, stdinWriter *os.File
+
+	if model.TTY {
+		if stdinWriter, stdinReader, err = openUnitPTY(command); err != nil {
+			logFile.Close()
+			return nil, err
+		}
+	} else if model.Stdin {
+		if stdinReader, stdinWriter, err = os.Pipe(); err != nil {
+			logFile.Close()
+			return nil, err
+		}
+
+		command.Stdin = stdinReader
+	}
 
 	s.unitsMu.Lock()
 	defer s.unitsMu.Unlock()
 
-	if err := command.Start(); err != nil {
-		cancel()
+	err = command.Start()
+
+	// the unit process holds its own copy of the read end
+	if stdinReader != nil {
+		stdinReader.Close()
+	}
+
+	if err != nil {
 		logFile.Close()
+
+		if stdinWriter != nil {
+			stdinWriter.Close()
+		}
+
 		return nil, err
 	}
 
-	unit := &Unit{
-		Model:     model,
-		Command:   command,
-		LogFile:   logFile,
-		StartedAt: time.Now(),
-		Cancel:    cancel,
+	unit := s.newUnit(model, command.Process, time.Now())
+	unit.Command = command
+	unit.LogFile = logFile
+	unit.env = env
+	unit.stdin = stdinWriter
+
+	var ptyOutputDone <-chan struct{}
+
+	if model.TTY {
+		ptyOutputDone = copyPTYOutput(stdinWriter, logFile, model.StripANSI)
 	}
 
+	go func() {
+		command.Wait()
+
+		if ptyOutputDone != nil {
+			select {
+			case <-ptyOutputDone:
+			case <-time.After(ptyDrainTimeout):
+			}
+		}
+
+		unit.closeStdin()
+
+		var exitSignal syscall.Signal
+
+		if waitStatus, ok := command.ProcessState.Sys().(syscall.WaitStatus); ok && waitStatus.Signaled() {
+			exitSignal = waitStatus.Signal()
+		}
+
+		unit.exit(command.ProcessState.ExitCode(), exitSignal)
+	}()
+
 	s.units[unit.Model.ID] = unit
+	s.watchers.Add(1)
 	go s.watchUnit(unit)
 
 	return unit, nil
 }
 
+// adoptUnit watches a unit process left running by the previous daemon. Its
+// exit code can't be known, so an unexpected exit is treated as a failure
+func (s *DaemonServer) adoptUnit(model UnitModel, processModel *UnitProcessModel) (*Unit, error) {
+	process, err := os.FindProcess(processModel.PID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	unit := s.newUnit(model, process, processModel.StartedAt)
+	unit.Adopted = true
+
+	// the env of the adopted process is only needed for its health checks and hooks
+	if unit.env, err = s.resolveUnitEnv(&model); err != nil {
+		slog.Warn("resolve adopted unit env", "id", model.ID, "err", err)
+		unit.env = model.Env
+	}
+
+	go func() {
+		for processAlive(processModel.PID, processModel.StartTime) {
+			time.Sleep(adoptedUnitPollInterval)
+		}
+
+		unit.exit(1, 0)
+	}()
+
+	s.unitsMu.Lock()
+	s.units[unit.Model.ID] = unit
+	s.unitsMu.Unlock()
+
+	s.watchers.Add(1)
+	go s.watchUnit(unit)
+
+	return unit, nil
+}
+
+// RestoreUnits starts the stored units on daemon boot, adopting the ones
+// that are still running after the previous daemon. Units stopped by the
+// user are kept stopped
+func (s *DaemonServer) RestoreUnits() error {
+	s.publishDaemonEvent(EventDaemonStarted, "")
+
+	db := s.Options.DBFactory()
+
+	var (
+		unitModels    []UnitModel
+		processModels []UnitProcessModel
+	)
+
+	if err := db.All(&unitModels); err != nil {
+		db.Close()
+		return err
+	}
+
+	if err := db.All(&processModels); err != nil {
+		db.Close()
+		return err
+	}
+
+	db.Close()
+
+	processModelsByUnitID := make(map[uint64]*UnitProcessModel, len(processModels))
+
+	for i := range processModels {
+		processModelsByUnitID[processModels[i].UnitID] = &processModels[i]
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(len(unitModels))
+
+	for _, unitModel := range unitModels {
+		model := unitModel
+
+		go func() {
+			defer wg.Done()
+
+			if model.DesiredState == UnitDesiredStateStopped {
+				s.unitsMu.Lock()
+				s.units[model.ID] = newStoppedUnit(model)
+				s.unitsMu.Unlock()
+				return
+			}
+
+			processModel := processModelsByUnitID[model.ID]
+
+			if processModel != nil && processAlive(processModel.PID, processModel.StartTime) {
+				if _, err := s.adoptUnit(model, processModel); err != nil {
+					slog.Error("adopt unit", "id", model.ID, "err", err)
+				}
+
+				return
+			}
+
+			if _, err := s.StartUnit(model); err != nil {
+				slog.Error("start unit", "id", model.ID, "err", err)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return nil
+}
+
+// Shutdown stops the units in reverse start order, or leaves them running
+// for the next daemon to adopt if detach is set
+func (s *DaemonServer) Shutdown(detach bool) {
+	s.shuttingDown.Store(true)
+
+	s.unitsMu.RLock()
+	units := make([]*Unit, 0, len(s.units))
+
+	for _, unit := range s.units {
+		units = append(units, unit)
+	}
+
+	s.unitsMu.RUnlock()
+
+	if detach {
+		for _, unit := range units {
+			if unit.LogFile != nil {
+				unit.LogFile.Close()
+			}
+		}
+
+		slog.Info("detached units", "count", len(units))
+		s.hookRuns.Wait()
+		s.historyWrites.Wait()
+		return
+	}
+
+	slices.SortFunc(units, func(a *Unit, b *Unit) int {
+		return b.StartedAt.Compare(a.StartedAt)
+	})
+
+	for _, unit := range units {
+		if unit.Status() != UnitStatusRunning {
+			continue
+		}
+
+		slog.Info("stopping unit", "id", unit.Model.ID)
+		s.stopUnit(unit, "daemon shutdown", "daemon")
+	}
+
+	s.watchers.Wait()
+	s.hookRuns.Wait()
+	s.historyWrites.Wait()
+}
+
 func (s *DaemonServer) stopUnitsStream(
 	unitIDs []uint64,
 	stream pb.ProcessService_StopServer,
 ) error {
+	caller := callerFromContext(stream.Context())
+	by := describeCaller(stream.Context())
 	eg, _ := errgroup.WithContext(stream.Context())
 
 	for _, unitID := range unitIDs {
 		id := unitID
 
 		eg.Go(func() error {
-			s.unitsMu.RLock()
-			unit := s.units[id]
-			s.unitsMu.RUnlock()
-
+			unit := s.getUnit(caller, id)
 			response := pb.StopResponse{UnitId: id}
 
 			if unit == nil {
 				response.Error = fmt.Sprintf("unit %d not found", id)
+				response.Code = uint32(codes.NotFound)
 				return stream.Send(&response)
 			}
 
 			if unit.Status() != UnitStatusRunning {
+				// a failed unit stays stopped after a daemon restart too
+				s.saveUnitDesiredState(unit, UnitDesiredStateStopped)
+
+				if unit.restartPending.CompareAndSwap(true, false) {
+					s.recordUnitEvent(unit, &UnitEventModel{
+						Type:   UnitEventStopped,
+						Reason: "stop requested, the pending restart is canceled",
+						By:     by,
+					})
+
+					response.Unit = unit.PB()
+					return stream.Send(&response)
+				}
+
 				response.Error = fmt.Sprintf(
 					"unit %s (%d) is not running",
-					unit.Model.Name,
+					unit.Name(),
 					unit.Model.ID,
 				)
 
+				response.Code = uint32(codes.FailedPrecondition)
 				response.Unit = unit.PB()
 				return stream.Send(&response)
 			}
 
-			unit.Stop()
+			s.stopUnit(unit, "stop requested", by)
+			s.saveUnitDesiredState(unit, UnitDesiredStateStopped)
+
 			response.Unit = unit.PB()
 			return stream.Send(&response)
 		})
@@ -168,41 +718,93 @@ func (s *DaemonServer) stopUnitsStream(
 	return eg.Wait()
 }
 
-func (s *DaemonServer) restartUnitsStream(
+func (s *DaemonServer) startUnitsStream(
 	unitIDs []uint64,
-	stream pb.ProcessService_RestartServer,
+	stream pb.ProcessService_StartExistingServer,
 ) error {
-	db := s.Options.DBFactory()
-	defer db.Close()
-
+	caller := callerFromContext(stream.Context())
 	eg, _ := errgroup.WithContext(stream.Context())
 
 	for _, unitID := range unitIDs {
 		id := unitID
 
 		eg.Go(func() error {
-			s.unitsMu.RLock()
-			unit := s.units[id]
-			s.unitsMu.RUnlock()
-
+			unit := s.getUnit(caller, id)
 			response := &pb.StopResponse{UnitId: id}
 
 			if unit == nil {
 				response.Error = fmt.Sprintf("unit %d not found", id)
+				response.Code = uint32(codes.NotFound)
 				return stream.Send(response)
 			}
 
 			if unit.Status() == UnitStatusRunning {
-				unit.Stop()
+				response.Error = fmt.Sprintf(
+					"unit %s (%d) is already running",
+					unit.Name(),
+					unit.Model.ID,
+				)
+
+				response.Code = uint32(codes.FailedPrecondition)
+				response.Unit = unit.PB()
+				return stream.Send(response)
+			}
+
+			model, err := s.updateUnitModel(unit, func(model *UnitModel) ([]string, error) {
+				model.DesiredState = UnitDesiredStateRunning
+				return []string{"DesiredState"}, nil
+			})
+
+			if err != nil {
+				response.Error = err.Error()
+				response.Code = uint32(codes.Internal)
+				response.Unit = unit.PB()
+				return stream.Send(response)
+			}
+
+			startedUnit, err := s.StartUnit(model)
+
+			if err != nil {
+				response.Error = err.Error()
+				response.Code = uint32(codes.Unknown)
+				response.Unit = unit.PB()
+				return stream.Send(response)
 			}
 
-			unit.Model.RestartsCount += 1
-			db.Update(&unit.Model)
+			response.Unit = startedUnit.PB()
+			return stream.Send(response)
+		})
+	}
+
+	return eg.Wait()
+}
+
+func (s *DaemonServer) restartUnitsStream(
+	unitIDs []uint64,
+	stream pb.ProcessService_RestartServer,
+) error {
+	caller := callerFromContext(stream.Context())
+	by := describeCaller(stream.Context())
+	eg, _ := errgroup.WithContext(stream.Context())
+
+	for _, unitID := range unitIDs {
+		id := unitID
+
+		eg.Go(func() error {
+			unit := s.getUnit(caller, id)
+			response := &pb.StopResponse{UnitId: id}
+
+			if unit == nil {
+				response.Error = fmt.Sprintf("unit %d not found", id)
+				response.Code = uint32(codes.NotFound)
+				return stream.Send(response)
+			}
 
-			unitCopy, err := s.StartUnit(unit.Model)
+			unitCopy, err := s.restartUnit(unit, "restart requested", by)
 
 			if err != nil {
 				response.Error = err.Error()
+				response.Code = uint32(codes.Unknown)
 				response.Unit = unit.PB()
 				return stream.Send(response)
 			}
@@ -215,33 +817,74 @@ func (s *DaemonServer) restartUnitsStream(
 	return eg.Wait()
 }
 
+// restartUnit stops the unit if it's running and starts it with its current model
+func (s *DaemonServer) restartUnit(unit *Unit, reason string, by string) (*Unit, error) {
+	if unit.Status() == UnitStatusRunning {
+		s.stopUnit(unit, reason, by)
+	}
+
+	model, err := s.updateUnitModel(unit, func(model *UnitModel) ([]string, error) {
+		model.RestartsCount += 1
+		model.DesiredState = UnitDesiredStateRunning
+		return []string{"RestartsCount", "DesiredState"}, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return s.StartUnit(model)
+}
+
 func (s *DaemonServer) deleteUnitsStream(
 	unitIDs []uint64,
 	stream pb.ProcessService_DeleteServer,
 ) error {
-	db := s.Options.DBFactory()
-	defer db.Close()
+	caller := callerFromContext(stream.Context())
+	by := describeCaller(stream.Context())
 
+	// the units are removed under the lock, so they can't be started again,
+	// and stopped after it's released, the pre-stop hooks and the stop
+	// timeout would block the other calls
+	units := make([]*Unit, len(unitIDs))
 	s.unitsMu.Lock()
-	defer s.unitsMu.Unlock()
+
+	for i, unitID := range unitIDs {
+		unit := s.units[unitID]
+
+		if unit == nil || !caller.CanAccess(&unit.Model) {
+			continue
+		}
+
+		delete(s.units, unitID)
+		units[i] = unit
+	}
+
+	s.unitsMu.Unlock()
 
 	eg, _ := errgroup.WithContext(stream.Context())
 
-	for _, unitID := range unitIDs {
+	for i, unitID := range unitIDs {
 		id := unitID
+		unit := units[i]
 
 		eg.Go(func() error {
-			unit := s.units[id]
-
 			if unit == nil {
 				response := &pb.StopResponse{UnitId: id}
 				response.Error = fmt.Sprintf("unit %d not found", id)
+				response.Code = uint32(codes.NotFound)
 				return stream.Send(response)
 			}
 
-			delete(s.units, unit.Model.ID)
-			unit.Stop()
-			db.DeleteStruct(&unit.Model)
+			s.stopUnit(unit, "delete requested", by)
+
+			db := s.Options.DBFactory()
+			model := unit.ModelCopy()
+			db.DeleteStruct(&model)
+			db.Close()
+
+			s.deleteUnitHistory(unit.Model.ID)
+			s.publishUnitEvent(EventDeleted, unit, nil)
 
 			slog.Info("deleted unit", "id", unit.Model.ID)
 
@@ -255,7 +898,7 @@ func (s *DaemonServer) deleteUnitsStream(
 	return eg.Wait()
 }
 
-func (s *DaemonServer) filterUnitIDs(except []uint64) []uint64 {
+func (s *DaemonServer) filterUnitIDs(caller *Caller, except []uint64) []uint64 {
 	s.unitsMu.RLock()
 	defer s.unitsMu.RUnlock()
 
@@ -263,6 +906,10 @@ func (s *DaemonServer) filterUnitIDs(except []uint64) []uint64 {
 
 unitLoop:
 	for _, unit := range s.units {
+		if !caller.CanAccess(&unit.Model) {
+			continue
+		}
+
 		for _, exceptedUnitID := range except {
 			if unit.Model.ID == exceptedUnitID {
 				continue unitLoop
@@ -279,64 +926,154 @@ func (s *DaemonServer) Start(
 	ctx context.Context,
 	request *pb.StartRequest,
 ) (*pb.StartResponse, error) {
-	db := s.Options.DBFactory()
-	defer db.Close()
-
-	tx, err := db.Begin(true)
+	unitModel, err := UnitModelFromStartRequest(request)
 
 	if err != nil {
-		return nil, status.Error(codes.Internal, err.Error())
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	defer tx.Rollback()
+	return s.createUnit(ctx, unitModel)
+}
 
+// UnitModelFromStartRequest validates the start request, it's used for
+// starting units and saving templates
+func UnitModelFromStartRequest(request *pb.StartRequest) (UnitModel, error) {
 	unitModel := UnitModel{
-		Name: request.Name,
-		Bin:  request.Bin,
-		CWD:  request.Cwd,
-		Args: request.Args,
-		Env:  request.Env,
+		Name:   request.Name,
+		Bin:    request.Bin,
+		CWD:    request.Cwd,
+		Args:   request.Args,
+		Env:    request.Env,
+		User:   request.User,
+		Group:  request.Group,
+		Groups: request.Groups,
+
+		RestartPolicy: request.RestartPolicy,
+		RestartDelay:  time.Duration(request.RestartDelayMs) * time.Millisecond,
+		StopSignal:    request.StopSignal,
+		StopTimeout:   time.Duration(request.StopTimeoutMs) * time.Millisecond,
+
+		HealthCmd:      request.HealthCmd,
+		HealthInterval: time.Duration(request.HealthIntervalMs) * time.Millisecond,
+
+		Labels:   request.Labels,
+		EnvMode:  request.EnvMode,
+		EnvAllow: request.EnvAllow,
+		EnvFiles: request.EnvFiles,
+		Stdin:    request.Stdin,
+
+		TTY:       request.Tty,
+		StripANSI: request.StripAnsi,
+	}
+
+	if len(unitModel.Bin) == 0 {
+		return unitModel, errors.New("bin can't be empty")
+	}
+
+	for _, hook := range request.Hooks {
+		hookConfig, err := ParseUnitHook(hook)
+
+		if err != nil {
+			return unitModel, err
+		}
+
+		unitModel.Hooks = append(unitModel.Hooks, hookConfig)
+	}
+
+	if len(unitModel.RestartPolicy) > 0 {
+		if _, err := ParseRestartPolicy(unitModel.RestartPolicy); err != nil {
+			return unitModel, err
+		}
+	}
+
+	if len(unitModel.StopSignal) > 0 {
+		if _, err := ParseSignal(unitModel.StopSignal); err != nil {
+			return unitModel, err
+		}
+	}
+
+	if err := ValidateLabels(unitModel.Labels); err != nil {
+		return unitModel, err
+	}
+
+	if len(unitModel.EnvMode) > 0 {
+		if _, err := ParseEnvMode(unitModel.EnvMode); err != nil {
+			return unitModel, err
+		}
+	}
+
+	return unitModel, nil
+}
+
+// createUnit saves a new unit owned by the caller and starts it
+func (s *DaemonServer) createUnit(ctx context.Context, unitModel UnitModel) (*pb.StartResponse, error) {
+	caller := callerFromContext(ctx)
+	unitModel.ID = 0
+	unitModel.OwnerUID = 0
+	unitModel.RestartsCount = 0
+	unitModel.DesiredState = UnitDesiredStateRunning
+
+	if caller != nil {
+		unitModel.OwnerUID = caller.UID
 	}
 
-	if err := tx.Save(&unitModel); err != nil {
+	if err := authorizeUnitCredential(caller, &unitModel); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	// the unit is saved before the start to get its id. The database isn't
+	// held during the start, pre-start hooks may take longer than storm waits for it
+	db := s.Options.DBFactory()
+	err := db.Save(&unitModel)
+	db.Close()
+
+	if err != nil {
 		return nil, status.Error(codes.Internal, err.Error())
 	}
 
 	unit, err := s.StartUnit(unitModel)
 
 	if err != nil {
+		db := s.Options.DBFactory()
+		db.DeleteStruct(&unitModel)
+		db.Close()
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	if err := tx.Commit(); err != nil {
-		s.unitsMu.Lock()
-		unit.Stop()
-		delete(s.units, unit.Model.ID)
-		s.unitsMu.Unlock()
-		return nil, status.Error(codes.Internal, err.Error())
-	}
-
 	var pid int32
 
 	if unit.Status() == UnitStatusRunning {
-		pid = int32(unit.Command.Process.Pid)
+		pid = int32(unit.Process.Pid)
 	}
 
 	response := pb.StartResponse{
-		Id:  unit.Model.ID,
-		Pid: pid,
+		Id:   unit.Model.ID,
+		Pid:  pid,
+		Name: unitModel.Name,
 	}
 
 	return &response, nil
 }
 
-func (s *DaemonServer) List(context.Context, *emptypb.Empty) (*pb.ListResponse, error) {
-	pbUnits := make([]*pb.Unit, len(s.units))
-	idx := 0
+func (s *DaemonServer) StartExisting(
+	request *pb.StopRequest,
+	stream pb.ProcessService_StartExistingServer,
+) error {
+	return s.startUnitsStream(request.UnitIds, stream)
+}
+
+func (s *DaemonServer) List(ctx context.Context, _ *emptypb.Empty) (*pb.ListResponse, error) {
+	caller := callerFromContext(ctx)
+
+	s.unitsMu.RLock()
+	defer s.unitsMu.RUnlock()
+
+	pbUnits := make([]*pb.Unit, 0, len(s.units))
 
 	for _, unit := range s.units {
-		pbUnits[idx] = unit.PB()
-		idx++
+		if caller.CanAccess(&unit.Model) {
+			pbUnits = append(pbUnits, unit.PB())
+		}
 	}
 
 	response := pb.ListResponse{
@@ -354,7 +1091,10 @@ func (s *DaemonServer) StopAll(
 	request *pb.ExceptRequest,
 	stream pb.ProcessService_StopAllServer,
 ) error {
-	return s.stopUnitsStream(s.filterUnitIDs(request.UnitIds), stream)
+	return s.stopUnitsStream(
+		s.filterUnitIDs(callerFromContext(stream.Context()), request.UnitIds),
+		stream,
+	)
 }
 
 func (s *DaemonServer) Restart(
@@ -368,18 +1108,16 @@ func (s *DaemonServer) RestartAll(
 	request *pb.ExceptRequest,
 	stream pb.ProcessService_RestartAllServer,
 ) error {
-	return s.restartUnitsStream(s.filterUnitIDs(request.UnitIds), stream)
+	return s.restartUnitsStream(
+		s.filterUnitIDs(callerFromContext(stream.Context()), request.UnitIds),
+		stream,
+	)
 }
 
 func (s *DaemonServer) Logs(request *pb.LogsRequest, stream pb.ProcessService_LogsServer) error {
-	const (
-		followInterval = time.Second
-		chunkSize      = 1024
-	)
+	const followInterval = time.Second
 
-	s.unitsMu.RLock()
-	unit := s.units[request.UnitId]
-	s.unitsMu.RUnlock()
+	unit := s.getUnit(callerFromContext(stream.Context()), request.UnitId)
 
 	if unit == nil {
 		return status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
@@ -394,78 +1132,15 @@ func (s *DaemonServer) Logs(request *pb.LogsRequest, stream pb.ProcessService_Lo
 
 	defer logFile.Close()
 
-	stat, err := logFile.Stat()
-
-	if err != nil {
-		return status.Error(codes.Internal, err.Error())
-	}
-
-	charBuf := make([]byte, chunkSize)
-	linesSent := uint64(0)
-	lineBuf := make([]byte, 0, chunkSize)
-	tailBreakPoint := -stat.Size()
-	tailCursor := int64(0)
-
 	response := pb.LogsResponse{}
 
-tailLoop:
-	for {
-		tailCursor = max(tailBreakPoint, tailCursor-chunkSize)
-
-		if _, err := logFile.Seek(tailCursor, io.SeekEnd); err != nil {
-			break
-		}
-
-		read, err := logFile.Read(charBuf)
-
-		if err != nil {
-			break
-		}
-
-		bytes := charBuf[:read]
-
-		for i := len(bytes) - 1; i >= 0; i-- {
-			char := bytes[i]
-
-			if char != '\n' && char != '\r' {
-				lineBuf = append(lineBuf, char)
-				continue
-			}
-
-			if len(lineBuf) == 0 {
-				continue
-			}
-
-			slices.Reverse(lineBuf)
-			response.Line = string(lineBuf)
-
-			if err := stream.Send(&response); err != nil {
-				return err
-			}
-
-			linesSent += 1
-
-			if linesSent >= request.Lines {
-				break tailLoop
-			}
-
-			lineBuf = lineBuf[:0]
-		}
-
-		if len(lineBuf) > 0 {
-			slices.Reverse(lineBuf)
-			response.Line = string(lineBuf)
-
-			if err := stream.Send(&response); err != nil {
-				return err
-			}
-		}
-
-		if tailCursor <= tailBreakPoint {
-			break
-		}
+	err = tailLogFile(logFile, request.Lines, func(line string) error {
+		response.Line = line
+		return stream.Send(&response)
+	})
 
-		clear(charBuf)
+	if err != nil {
+		return err
 	}
 
 	response.Flush = true
@@ -484,7 +1159,12 @@ tailLoop:
 	}
 
 	for {
-		time.Sleep(followInterval)
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-time.After(followInterval):
+		}
+
 		scanner := bufio.NewScanner(logFile)
 
 		for scanner.Scan() {
@@ -512,27 +1192,69 @@ func (s *DaemonServer) DeleteAll(
 	request *pb.ExceptRequest,
 	stream pb.ProcessService_DeleteAllServer,
 ) error {
-	return s.deleteUnitsStream(s.filterUnitIDs(request.UnitIds), stream)
+	return s.deleteUnitsStream(
+		s.filterUnitIDs(callerFromContext(stream.Context()), request.UnitIds),
+		stream,
+	)
 }
 
 func (s *DaemonServer) Show(
 	ctx context.Context,
 	request *pb.ShowRequest,
 ) (*pb.ShowResponse, error) {
-	s.unitsMu.RLock()
-	unit := s.units[request.UnitId]
-	s.unitsMu.RUnlock()
+	unit := s.getUnit(callerFromContext(ctx), request.UnitId)
 
 	if unit == nil {
 		return nil, status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
 	}
 
+	history, err := s.getUnitHistory(unit.Model.ID, showHistoryEvents)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pbHistory := make([]*pb.UnitEvent, len(history))
+
+	for i := range history {
+		pbHistory[i] = history[i].PB()
+	}
+
+	model := unit.ModelCopy()
+	restartPolicy, restartDelay := s.unitRestartPolicy(&model)
+	stopSignal, stopTimeout := s.unitStopPolicy(&model)
 	response := pb.ShowResponse{
-		Id:      unit.Model.ID,
-		Name:    unit.Model.Name,
-		Cwd:     unit.Model.CWD,
-		Command: strings.Join(unit.Command.Args, " "),
-		Env:     unit.Model.Env,
+		Id:       model.ID,
+		Name:     model.Name,
+		Cwd:      model.CWD,
+		Command:  strings.Join(append([]string{model.Bin}, model.Args...), " "),
+		Env:      MaskEnv(model.Env),
+		User:     model.User,
+		Group:    model.Group,
+		Groups:   model.Groups,
+		OwnerUid: model.OwnerUID,
+
+		RestartPolicy:  restartPolicy,
+		RestartDelayMs: restartDelay.Milliseconds(),
+		StopSignal:     SignalName(stopSignal),
+		StopTimeoutMs:  stopTimeout.Milliseconds(),
+
+		Health:           unit.PB().Health,
+		HealthCmd:        model.HealthCmd,
+		HealthIntervalMs: s.unitHealthInterval(&model).Milliseconds(),
+		History:          pbHistory,
+		Hooks:            make([]string, len(model.Hooks)),
+		EnvFiles:         model.EnvFiles,
+		Labels:           model.Labels,
+		Stdin:            model.Stdin,
+		Tty:              model.TTY,
+		StripAnsi:        model.StripANSI,
+	}
+
+	response.EnvMode, response.EnvAllow = s.unitEnvMode(&model)
+
+	for i, hook := range model.Hooks {
+		response.Hooks[i] = hook.String()
 	}
 
 	return &response, nil
@@ -542,8 +1264,10 @@ func (s *DaemonServer) LogsClear(
 	ctx context.Context,
 	request *pb.LogsClearRequest,
 ) (*emptypb.Empty, error) {
+	caller := callerFromContext(ctx)
+
 	for _, unitID := range request.UnitIds {
-		if s.units[unitID] == nil {
+		if s.getUnit(caller, unitID) == nil {
 			continue
 		}
 
@@ -560,29 +1284,135 @@ func (s *DaemonServer) Update(
 	ctx context.Context,
 	request *pb.UpdateRequst,
 ) (*pb.UpdateResponse, error) {
-	s.unitsMu.Lock()
-	defer s.unitsMu.Unlock()
+	caller := callerFromContext(ctx)
+	unit := s.getUnit(caller, request.UnitId)
 
-	unit := s.units[request.UnitId]
+	if unit == nil {
+		return nil, status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
+	}
+
+	var changes []*pb.UnitFieldChange
+
+	updatedModel, err := s.updateUnitModel(unit, func(model *UnitModel) ([]string, error) {
+		var err error
+
+		if *model, changes, err = UpdateUnitModel(*model, request); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		if err := authorizeUnitCredential(caller, model); err != nil {
+			return nil, status.Error(codes.PermissionDenied, err.Error())
+		}
+
+		// only the changed fields are saved, db.Save would overwrite the
+		// fields changed concurrently, like the restarts count
+		fields := make([]string, len(changes))
+
+		for i, change := range changes {
+			fields[i] = unitModelFields[change.Field]
+		}
+
+		return fields, nil
+	})
+
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := pb.UpdateResponse{
+		Name:    updatedModel.Name,
+		Changes: changes,
+	}
+
+	s.publishUnitEvent(EventUpdated, unit, nil)
+
+	if request.Restart && len(response.Changes) > 0 && unit.Status() == UnitStatusRunning {
+		restartedUnit, err := s.restartUnit(unit, "update requested", describeCaller(ctx))
+
+		if err != nil {
+			response.RestartError = err.Error()
+		} else {
+			unit = restartedUnit
+		}
+	}
+
+	response.Unit = unit.PB()
+	return &response, nil
+}
+
+// Clone creates and starts a copy of the unit with the overrides applied
+func (s *DaemonServer) Clone(
+	ctx context.Context,
+	request *pb.CloneRequest,
+) (*pb.StartResponse, error) {
+	unit := s.getUnit(callerFromContext(ctx), request.UnitId)
 
 	if unit == nil {
 		return nil, status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
 	}
 
-	if len(request.Name) > 0 {
-		unit.Model.Name = request.Name
+	unitModel := unit.ModelCopy()
+
+	if request.Overrides != nil {
+		paths, mergeEnv := updateMaskPaths(request.Overrides)
+
+		if err := applyUnitUpdate(&unitModel, request.Overrides, paths, mergeEnv); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 	}
 
-	if len(request.Env) > 0 {
-		unit.Model.Env = updateEnv(unit.Model.Env, request.Env)
+	return s.createUnit(ctx, unitModel)
+}
+
+func (s *DaemonServer) History(
+	ctx context.Context,
+	request *pb.HistoryRequest,
+) (*pb.HistoryResponse, error) {
+	if s.getUnit(callerFromContext(ctx), request.UnitId) == nil {
+		return nil, status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
 	}
 
-	db := s.Options.DBFactory()
-	db.Update(&unit.Model)
-	db.Close()
+	limit := int(request.Limit)
 
-	response := pb.UpdateResponse{
-		Name: unit.Model.Name,
+	if limit == 0 {
+		limit = defaultHistoryEvents
+	}
+
+	history, err := s.getUnitHistory(request.UnitId, limit)
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := pb.HistoryResponse{
+		Events: make([]*pb.UnitEvent, len(history)),
+	}
+
+	for i := range history {
+		response.Events[i] = history[i].PB()
+	}
+
+	return &response, nil
+}
+
+func (s *DaemonServer) Config(ctx context.Context, _ *emptypb.Empty) (*pb.ConfigResponse, error) {
+	if !callerFromContext(ctx).IsAdmin() {
+		return nil, status.Error(codes.PermissionDenied, "only root can read the daemon config")
+	}
+
+	config, err := s.getConfig().Redacted().YAML()
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := pb.ConfigResponse{
+		Config:   config,
+		Filepath: s.Options.ConfigFilepath,
 	}
 
 	return &response, nil
@@ -615,11 +1445,11 @@ func updateEnv(currentEnv []string, newEnv []string) []string {
 	return updatedEnv
 }
 
-func createUnitStartCommand(ctx context.Context, model *UnitModel, logFile *os.File) *exec.Cmd {
-	command := exec.CommandContext(ctx, model.Bin, model.Args...)
-	command.Env = model.Env
+func createUnitStartCommand(model *UnitModel, env []string, output io.Writer) *exec.Cmd {
+	command := exec.Command(model.Bin, model.Args...)
+	command.Env = env
 	command.Dir = model.CWD
-	command.Stdout = logFile
-	command.Stderr = logFile
+	command.Stdout = output
+	command.Stderr = output
 	return command
 }
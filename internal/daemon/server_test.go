@@ -0,0 +1,196 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/asdine/storm/v3"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// newTestServer returns a daemon server with its data in a temporary
+// directory, the units are stopped when the test ends
+func newTestServer(t *testing.T) *DaemonServer {
+	t.Helper()
+
+	dataDirpath := t.TempDir()
+	config := DefaultConfig(dataDirpath, path.Join(dataDirpath, "pm0.sock"))
+
+	if err := os.MkdirAll(config.LogsDirpath(), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	dbFilepath := path.Join(dataDirpath, config.DBFile)
+	server := NewDaemonServer(DaemonServerOptions{
+		LogsDirpath: config.LogsDirpath(),
+		DBFactory: func() *storm.DB {
+			db, err := storm.Open(dbFilepath)
+
+			if err != nil {
+				panic(err)
+			}
+
+			return db
+		},
+		Config: config,
+	})
+
+	t.Cleanup(func() {
+		server.Shutdown(false)
+	})
+
+	return server
+}
+
+// testContext is the context of an in-process caller managing every unit
+func testContext() context.Context {
+	return ContextWithCaller(context.Background(), &Caller{Network: true})
+}
+
+// collectUnitResults calls an RPC streaming unit results in-process
+func collectUnitResults(
+	t *testing.T,
+	call func(stream pb.ProcessService_StopServer) error,
+) []*pb.StopResponse {
+	t.Helper()
+
+	var responses []*pb.StopResponse
+
+	stream := newLocalStream(testContext(), func(response *pb.StopResponse) error {
+		responses = append(responses, response)
+		return nil
+	})
+
+	if err := call(stream); err != nil {
+		t.Fatal(err)
+	}
+
+	return responses
+}
+
+func TestDeleteDoesNotBlockList(t *testing.T) {
+	const stopTimeout = 2 * time.Second
+
+	s := newTestServer(t)
+	ctx := testContext()
+
+	// the shell and its sleep ignore SIGTERM, so the stop waits for the timeout
+	response, err := s.Start(ctx, &pb.StartRequest{
+		Name:          "stubborn",
+		Bin:           "sh",
+		Args:          []string{"-c", "trap '' TERM; sleep 30"},
+		StopTimeoutMs: stopTimeout.Milliseconds(),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	deleted := make(chan []*pb.StopResponse)
+
+	go func() {
+		deleted <- collectUnitResults(t, func(stream pb.ProcessService_StopServer) error {
+			return s.Delete(&pb.StopRequest{UnitIds: []uint64{response.Id}}, stream)
+		})
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	listed := make(chan *pb.ListResponse)
+	start := time.Now()
+
+	go func() {
+		list, err := s.List(ctx, &emptypb.Empty{})
+
+		if err != nil {
+			t.Error(err)
+		}
+
+		listed <- list
+	}()
+
+	select {
+	case list := <-listed:
+		if elapsed := time.Since(start); elapsed > stopTimeout/2 {
+			t.Fatalf("list took %s during the delete", elapsed)
+		}
+
+		if len(list.Units) != 0 {
+			t.Fatalf("list returned %d units, the deleted unit should be gone", len(list.Units))
+		}
+	case <-deleted:
+		t.Fatal("list was blocked until the delete finished")
+	}
+
+	results := <-deleted
+
+	if len(results) != 1 || len(results[0].Error) > 0 {
+		t.Fatalf("unexpected delete results %v", results)
+	}
+}
+
+func TestStopCancelsPendingRestart(t *testing.T) {
+	const restartDelay = 500 * time.Millisecond
+
+	s := newTestServer(t)
+
+	response, err := s.Start(testContext(), &pb.StartRequest{
+		Name:           "failing",
+		Bin:            "sh",
+		Args:           []string{"-c", "exit 1"},
+		RestartPolicy:  RestartPolicyOnFailure,
+		RestartDelayMs: restartDelay.Milliseconds(),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	time.Sleep(restartDelay / 2)
+
+	results := collectUnitResults(t, func(stream pb.ProcessService_StopServer) error {
+		return s.Stop(&pb.StopRequest{UnitIds: []uint64{response.Id}}, stream)
+	})
+
+	if len(results) != 1 || len(results[0].Error) > 0 {
+		t.Fatalf("unexpected stop results %v", results)
+	}
+
+	time.Sleep(restartDelay)
+
+	unit := s.getUnit(&Caller{Network: true}, response.Id)
+
+	if status := unit.Status(); status != UnitStatusFailed {
+		t.Fatalf("unit status %d, expected the failed unit not to be restarted", status)
+	}
+
+	var model UnitModel
+
+	db := s.Options.DBFactory()
+	err = db.One("ID", response.Id, &model)
+	db.Close()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if model.DesiredState != UnitDesiredStateStopped {
+		t.Fatalf("desired state %d, expected the unit to stay stopped", model.DesiredState)
+	}
+
+	// without a pending restart there is nothing to stop
+	results = collectUnitResults(t, func(stream pb.ProcessService_StopServer) error {
+		return s.Stop(&pb.StopRequest{UnitIds: []uint64{response.Id}}, stream)
+	})
+
+	if len(results) != 1 || !strings.Contains(results[0].Error, "is not running") {
+		t.Fatalf("unexpected second stop results %v", results)
+	}
+}
@@ -0,0 +1,138 @@
+package daemon
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/sys/unix"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ParseSignal accepts signal names with or without the SIG prefix and numbers
+func ParseSignal(name string) (syscall.Signal, error) {
+	if number, err := strconv.Atoi(name); err == nil {
+		if unix.SignalName(syscall.Signal(number)) == "" {
+			return 0, fmt.Errorf("unknown signal %d", number)
+		}
+
+		return syscall.Signal(number), nil
+	}
+
+	name = strings.ToUpper(name)
+
+	if !strings.HasPrefix(name, "SIG") {
+		name = "SIG" + name
+	}
+
+	signal := unix.SignalNum(name)
+
+	if signal == 0 {
+		return 0, fmt.Errorf("unknown signal %s", name)
+	}
+
+	return signal, nil
+}
+
+func SignalName(signal syscall.Signal) string {
+	if name := unix.SignalName(signal); len(name) > 0 {
+		return name
+	}
+
+	return strconv.Itoa(int(signal))
+}
+
+func (s *DaemonServer) Signal(request *pb.SignalRequest, stream pb.ProcessService_SignalServer) error {
+	return s.signalUnitsStream(request.UnitIds, request.Signal, request.Group, stream)
+}
+
+func (s *DaemonServer) SignalAll(
+	request *pb.SignalAllRequest,
+	stream pb.ProcessService_SignalAllServer,
+) error {
+	return s.signalUnitsStream(
+		s.filterUnitIDs(callerFromContext(stream.Context()), request.Except),
+		request.Signal,
+		request.Group,
+		stream,
+	)
+}
+
+// signalUnitsStream sends the signal to the running units. The unit is looked
+// up right before the signal, so it goes to the current process after a restart
+func (s *DaemonServer) signalUnitsStream(
+	unitIDs []uint64,
+	signalName string,
+	group bool,
+	stream pb.ProcessService_SignalServer,
+) error {
+	signal, err := ParseSignal(signalName)
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	caller := callerFromContext(stream.Context())
+	by := describeCaller(stream.Context())
+	eg, _ := errgroup.WithContext(stream.Context())
+
+	for _, unitID := range unitIDs {
+		id := unitID
+
+		eg.Go(func() error {
+			unit := s.getUnit(caller, id)
+			response := &pb.StopResponse{UnitId: id}
+
+			if unit == nil {
+				response.Error = fmt.Sprintf("unit %d not found", id)
+				response.Code = uint32(codes.NotFound)
+				return stream.Send(response)
+			}
+
+			response.Unit = unit.PB()
+
+			if unit.Status() != UnitStatusRunning {
+				response.Error = fmt.Sprintf(
+					"unit %s (%d) is not running",
+					unit.Name(),
+					unit.Model.ID,
+				)
+
+				response.Code = uint32(codes.FailedPrecondition)
+				return stream.Send(response)
+			}
+
+			// the process group shares the unit pid, the main process is
+			// signaled through os.Process so an exited pid isn't reused
+			var signalErr error
+
+			if group {
+				signalErr = syscall.Kill(-unit.Process.Pid, signal)
+			} else {
+				signalErr = unit.Process.Signal(signal)
+			}
+
+			if signalErr != nil {
+				response.Error = fmt.Sprintf(
+					"signal unit %s (%d): %s",
+					unit.Name(),
+					unit.Model.ID,
+					signalErr,
+				)
+
+				response.Code = uint32(codes.FailedPrecondition)
+				return stream.Send(response)
+			}
+
+			slog.Info("signaled unit", "id", unit.Model.ID, "signal", SignalName(signal), "group", group, "by", by)
+			return stream.Send(response)
+		})
+	}
+
+	return eg.Wait()
+}
@@ -0,0 +1,303 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	// stdinWriteTimeout fails the writes to units that don't read their stdin
+	// instead of blocking once the pipe buffer is full
+	stdinWriteTimeout  = 5 * time.Second
+	attachPollInterval = 100 * time.Millisecond
+	attachChunkSize    = 32 * 1024
+)
+
+var errUnitStdinClosed = errors.New("stdin is closed")
+
+// WriteStdin writes to the stdin pipe of the unit process
+func (u *Unit) WriteStdin(data []byte) error {
+	u.stdinMu.Lock()
+	defer u.stdinMu.Unlock()
+
+	if u.stdin == nil {
+		return errUnitStdinClosed
+	}
+
+	if err := u.stdin.SetWriteDeadline(time.Now().Add(stdinWriteTimeout)); err != nil {
+		return err
+	}
+
+	_, err := u.stdin.Write(data)
+
+	if errors.Is(err, os.ErrDeadlineExceeded) {
+		return fmt.Errorf("unit doesn't read its stdin, the write timed out after %s", stdinWriteTimeout)
+	}
+
+	return err
+}
+
+func (u *Unit) closeStdin() {
+	u.stdinMu.Lock()
+	defer u.stdinMu.Unlock()
+
+	if u.stdin != nil {
+		u.stdin.Close()
+		u.stdin = nil
+	}
+}
+
+// writeUnitStdin looks the unit up on every write, so the input goes to the
+// current process after a restart
+func (s *DaemonServer) writeUnitStdin(caller *Caller, unitID uint64, data []byte) error {
+	unit := s.getUnit(caller, unitID)
+
+	if unit == nil {
+		return status.Errorf(codes.NotFound, "unit %d not found", unitID)
+	}
+
+	if err := unitStdinAvailable(unit); err != nil {
+		return err
+	}
+
+	if err := unit.WriteStdin(data); err != nil {
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"write to unit %s (%d): %s",
+			unit.Name(),
+			unit.Model.ID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+// resizeUnitPTY sets the window size of the unit pseudo-terminal, the size
+// of the units without one is ignored
+func (s *DaemonServer) resizeUnitPTY(caller *Caller, unitID uint64, rows uint32, cols uint32) error {
+	unit := s.getUnit(caller, unitID)
+
+	if unit == nil {
+		return status.Errorf(codes.NotFound, "unit %d not found", unitID)
+	}
+
+	if !unit.ModelCopy().TTY || unit.Adopted {
+		return nil
+	}
+
+	if err := unit.Resize(rows, cols); err != nil && !errors.Is(err, errUnitStdinClosed) {
+		return status.Errorf(
+			codes.Internal,
+			"resize the terminal of unit %s (%d): %s",
+			unit.Name(),
+			unit.Model.ID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func unitStdinAvailable(unit *Unit) error {
+	if model := unit.ModelCopy(); !model.Stdin && !model.TTY {
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"unit %s (%d) has no stdin, enable it with pm0 update --stdin --restart %d",
+			unit.Name(),
+			unit.Model.ID,
+			unit.Model.ID,
+		)
+	}
+
+	if unit.Status() != UnitStatusRunning {
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"unit %s (%d) is not running",
+			unit.Name(),
+			unit.Model.ID,
+		)
+	}
+
+	// the pipe or terminal of the processes adopted after a daemon restart is gone
+	if unit.Adopted {
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"stdin of unit %s (%d) was lost on the daemon restart, restart the unit",
+			unit.Name(),
+			unit.Model.ID,
+		)
+	}
+
+	return nil
+}
+
+func (s *DaemonServer) Send(ctx context.Context, request *pb.SendRequest) (*emptypb.Empty, error) {
+	if err := s.writeUnitStdin(callerFromContext(ctx), request.UnitId, request.Data); err != nil {
+		return nil, err
+	}
+
+	return emptyResponse, nil
+}
+
+// Attach replays the last log lines of the unit, then streams its output and
+// forwards the input of the client to the unit stdin until the client closes
+// its side of the stream. The unit keeps running after the client detaches.
+// The window size sent by the client resizes the terminal of tty units
+func (s *DaemonServer) Attach(stream pb.ProcessService_AttachServer) error {
+	request, err := stream.Recv()
+
+	if err != nil {
+		return err
+	}
+
+	caller := callerFromContext(stream.Context())
+	unit := s.getUnit(caller, request.UnitId)
+
+	if unit == nil {
+		return status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
+	}
+
+	if err := unitStdinAvailable(unit); err != nil {
+		return err
+	}
+
+	logFile, err := os.OpenFile(s.getUnitLogFilepath(unit.Model.ID), os.O_RDONLY, logFilePerm)
+
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	defer logFile.Close()
+
+	var replay []string
+
+	err = tailLogFile(logFile, request.Lines, func(line string) error {
+		replay = append(replay, line)
+		return nil
+	})
+
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	var output []byte
+
+	// the terminal of the client is in raw mode when attached to a tty unit
+	lineSeparator := "\n"
+	tty := unit.ModelCopy().TTY
+
+	if tty {
+		lineSeparator = "\r\n"
+	}
+
+	if len(replay) > 0 {
+		slices.Reverse(replay)
+		output = []byte(strings.Join(replay, lineSeparator) + lineSeparator)
+	}
+
+	// the replay is sent even if it's empty, it tells the client it's attached
+	if err := stream.Send(&pb.AttachResponse{Data: output, Tty: tty}); err != nil {
+		return err
+	}
+
+	if _, err := logFile.Seek(0, io.SeekEnd); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	inputDone := make(chan error, 1)
+
+	go func() {
+		if request.Rows > 0 && request.Cols > 0 {
+			if err := s.resizeUnitPTY(caller, request.UnitId, request.Rows, request.Cols); err != nil {
+				inputDone <- err
+				return
+			}
+		}
+
+		if len(request.Data) > 0 {
+			if err := s.writeUnitStdin(caller, request.UnitId, request.Data); err != nil {
+				inputDone <- err
+				return
+			}
+		}
+
+		for {
+			input, err := stream.Recv()
+
+			if errors.Is(err, io.EOF) {
+				inputDone <- nil
+				return
+			}
+
+			if err != nil {
+				inputDone <- err
+				return
+			}
+
+			if input.Rows > 0 && input.Cols > 0 {
+				if err := s.resizeUnitPTY(caller, request.UnitId, input.Rows, input.Cols); err != nil {
+					inputDone <- err
+					return
+				}
+			}
+
+			if len(input.Data) == 0 {
+				continue
+			}
+
+			if err := s.writeUnitStdin(caller, request.UnitId, input.Data); err != nil {
+				inputDone <- err
+				return
+			}
+		}
+	}()
+
+	ticker := time.NewTicker(attachPollInterval)
+	defer ticker.Stop()
+
+	chunk := make([]byte, attachChunkSize)
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case err := <-inputDone:
+			return err
+		case <-ticker.C:
+		}
+
+		if s.getUnit(caller, request.UnitId) == nil {
+			return status.Errorf(codes.NotFound, "unit %d was deleted", request.UnitId)
+		}
+
+		for {
+			read, err := logFile.Read(chunk)
+
+			if read > 0 {
+				if err := stream.Send(&pb.AttachResponse{Data: chunk[:read]}); err != nil {
+					return err
+				}
+			}
+
+			if errors.Is(err, io.EOF) {
+				break
+			}
+
+			if err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
+		}
+	}
+}
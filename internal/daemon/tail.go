@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"errors"
+	"io"
+	"os"
+	"slices"
+)
+
+// tailLogFile calls fn with up to lines last lines of the log file, starting
+// from the last one. Empty lines are skipped
+func tailLogFile(logFile *os.File, lines uint64, fn func(line string) error) error {
+	const chunkSize = 1024
+
+	if lines == 0 {
+		return nil
+	}
+
+	stat, err := logFile.Stat()
+
+	if err != nil {
+		return err
+	}
+
+	chunk := make([]byte, chunkSize)
+	lineBuf := make([]byte, 0, chunkSize)
+	linesRead := uint64(0)
+	cursor := stat.Size()
+
+	for cursor > 0 {
+		readSize := min(chunkSize, cursor)
+		cursor -= readSize
+		read, err := logFile.ReadAt(chunk[:readSize], cursor)
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+
+		for i := read - 1; i >= 0; i-- {
+			char := chunk[i]
+
+			if char != '\n' && char != '\r' {
+				lineBuf = append(lineBuf, char)
+				continue
+			}
+
+			if len(lineBuf) == 0 {
+				continue
+			}
+
+			slices.Reverse(lineBuf)
+
+			if err := fn(string(lineBuf)); err != nil {
+				return err
+			}
+
+			linesRead += 1
+
+			if linesRead >= lines {
+				return nil
+			}
+
+			lineBuf = lineBuf[:0]
+		}
+	}
+
+	if len(lineBuf) == 0 {
+		return nil
+	}
+
+	slices.Reverse(lineBuf)
+	return fn(string(lineBuf))
+}
@@ -0,0 +1,232 @@
+package daemon
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/asdine/storm/v3"
+	"github.com/asdine/storm/v3/q"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	templateInstanceSeparator = "@"
+	templateInstanceParameter = "%i"
+	templateLabel             = "template"
+	templateInstanceLabel     = "instance"
+)
+
+var (
+	templateNameRegexp     = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,128}$`)
+	templateInstanceRegexp = regexp.MustCompile(`^[A-Za-z0-9_.:-]{1,128}$`)
+)
+
+// UnitTemplateModel is a unit started as <template>@<instance> units,
+// with %i replaced by the instance name. Templates are namespaced by their owner
+type UnitTemplateModel struct {
+	ID       string `storm:"id"`
+	Name     string
+	OwnerUID uint32 `storm:"index"`
+	Unit     UnitModel
+}
+
+func (m *UnitTemplateModel) PB() *pb.Template {
+	return &pb.Template{
+		Name:     m.Name,
+		OwnerUid: m.OwnerUID,
+		Command:  strings.Join(append([]string{m.Unit.Bin}, m.Unit.Args...), " "),
+	}
+}
+
+// ParseTemplateInstance splits "<template>@<instance>" unit names
+func ParseTemplateInstance(name string) (string, string, bool) {
+	template, instance, ok := strings.Cut(name, templateInstanceSeparator)
+
+	if !ok || !templateNameRegexp.MatchString(template) || !templateInstanceRegexp.MatchString(instance) {
+		return "", "", false
+	}
+
+	return template, instance, true
+}
+
+// instantiateTemplate replaces %i in the command, cwd, env and health command
+// of the template unit and labels the instance with its template
+func instantiateTemplate(template *UnitTemplateModel, instance string) UnitModel {
+	model := template.Unit
+	replace := func(value string) string {
+		return strings.ReplaceAll(value, templateInstanceParameter, instance)
+	}
+
+	replaceAll := func(values []string) []string {
+		replaced := make([]string, len(values))
+
+		for i, value := range values {
+			replaced[i] = replace(value)
+		}
+
+		return replaced
+	}
+
+	model.Name = template.Name + templateInstanceSeparator + instance
+	model.Bin = replace(model.Bin)
+	model.Args = replaceAll(model.Args)
+	model.CWD = replace(model.CWD)
+	model.Env = replaceAll(model.Env)
+	model.EnvFiles = replaceAll(model.EnvFiles)
+	model.HealthCmd = replace(model.HealthCmd)
+	model.Labels = mergeLabels(model.Labels, map[string]string{
+		templateLabel:         template.Name,
+		templateInstanceLabel: instance,
+	})
+
+	return model
+}
+
+func (s *DaemonServer) TemplateSet(
+	ctx context.Context,
+	request *pb.TemplateSetRequest,
+) (*emptypb.Empty, error) {
+	if !templateNameRegexp.MatchString(request.Name) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"template name %q should only have letters, digits, _, . and -",
+			request.Name,
+		)
+	}
+
+	if request.Unit == nil || len(request.Unit.Bin) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "template should have a bin")
+	}
+
+	unitModel, err := UnitModelFromStartRequest(request.Unit)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	// the instances are authorized again on start, this only reports the
+	// templates that could never be started by the caller early
+	if err := authorizeUnitCredential(callerFromContext(ctx), &unitModel); err != nil {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	ownerUID := ownerFromContext(ctx)
+	template := UnitTemplateModel{
+		ID:       ownedID(ownerUID, request.Name),
+		Name:     request.Name,
+		OwnerUID: ownerUID,
+		Unit:     unitModel,
+	}
+
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	if err := db.Save(&template); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return emptyResponse, nil
+}
+
+// TemplateList returns the templates of the caller. Root sees the templates of every user
+func (s *DaemonServer) TemplateList(
+	ctx context.Context,
+	_ *emptypb.Empty,
+) (*pb.TemplateListResponse, error) {
+	var templates []UnitTemplateModel
+
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	query := db.Select().OrderBy("ID")
+
+	if ownerUID := ownerFromContext(ctx); ownerUID != 0 {
+		query = db.Select(q.Eq("OwnerUID", ownerUID)).OrderBy("ID")
+	}
+
+	if err := query.Find(&templates); err != nil && err != storm.ErrNotFound {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	response := pb.TemplateListResponse{Templates: make([]*pb.Template, len(templates))}
+
+	for i := range templates {
+		response.Templates[i] = templates[i].PB()
+	}
+
+	return &response, nil
+}
+
+func (s *DaemonServer) TemplateDelete(
+	ctx context.Context,
+	request *pb.TemplateRequest,
+) (*emptypb.Empty, error) {
+	db := s.Options.DBFactory()
+	defer db.Close()
+
+	err := db.DeleteStruct(&UnitTemplateModel{ID: ownedID(ownerFromContext(ctx), request.Name)})
+
+	if err == storm.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "template %s not found", request.Name)
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return emptyResponse, nil
+}
+
+// StartTemplate creates and starts the <template>@<instance> unit. Instances
+// are copies of the template, changing the template doesn't change them
+func (s *DaemonServer) StartTemplate(
+	ctx context.Context,
+	request *pb.StartTemplateRequest,
+) (*pb.StartResponse, error) {
+	if !templateInstanceRegexp.MatchString(request.Instance) {
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"instance name %q should only have letters, digits, _, ., : and -",
+			request.Instance,
+		)
+	}
+
+	var template UnitTemplateModel
+
+	db := s.Options.DBFactory()
+	err := db.One("ID", ownedID(ownerFromContext(ctx), request.Template), &template)
+	db.Close()
+
+	if err == storm.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "template %s not found", request.Template)
+	}
+
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	unitModel := instantiateTemplate(&template, request.Instance)
+	caller := callerFromContext(ctx)
+
+	s.unitsMu.RLock()
+
+	for _, unit := range s.units {
+		if unit.Name() == unitModel.Name && caller.CanAccess(&unit.Model) {
+			s.unitsMu.RUnlock()
+			return nil, status.Errorf(
+				codes.AlreadyExists,
+				"unit %s already exists, start it with pm0 start %d",
+				unitModel.Name,
+				unit.Model.ID,
+			)
+		}
+	}
+
+	s.unitsMu.RUnlock()
+
+	return s.createUnit(ctx, unitModel)
+}
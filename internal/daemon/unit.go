@@ -3,6 +3,9 @@ package daemon
 import (
 	"os"
 	"os/exec"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
 	"github.com/TrixiS/pm0/internal/daemon/pb"
@@ -17,67 +20,183 @@ const (
 	UnitStatusStopped UnitStatus = 3
 )
 
+// UnitDesiredState is the state the unit should be in after a daemon restart.
+// The zero value keeps the units stored before it was introduced running
+type UnitDesiredState uint32
+
+const (
+	UnitDesiredStateRunning UnitDesiredState = 0
+	UnitDesiredStateStopped UnitDesiredState = 1
+)
+
 type UnitModel struct {
-	ID            uint64 `storm:"id,increment"`
-	Name          string
-	CWD           string
-	Bin           string
-	Args          []string
-	RestartsCount uint32
-	Env           []string
+	ID             uint64 `storm:"id,increment"`
+	Name           string
+	CWD            string
+	Bin            string
+	Args           []string
+	RestartsCount  uint32
+	Env            []string
+	EnvMode        string
+	EnvAllow       []string
+	EnvFiles       []string
+	Labels         map[string]string
+	User           string
+	Group          string
+	Groups         []string
+	OwnerUID       uint32
+	RestartPolicy  string
+	RestartDelay   time.Duration
+	StopSignal     string
+	StopTimeout    time.Duration
+	DesiredState   UnitDesiredState
+	HealthCmd      string
+	HealthInterval time.Duration
+	Hooks          []HookConfig
+	Stdin          bool
+	TTY            bool
+	StripANSI      bool
+}
+
+// UnitProcessModel is stored while the unit process is running, so the next
+// daemon can adopt the process instead of starting a second copy
+type UnitProcessModel struct {
+	UnitID    uint64 `storm:"id"`
+	PID       int
+	StartTime uint64
+	StartedAt time.Time
+}
+
+// unitStop is the reason of a unit stop and the caller who requested it
+type unitStop struct {
+	reason string
+	by     string
 }
 
 type Unit struct {
+	// Model is changed under modelMu, copy it with ModelCopy. Its id and
+	// owner never change and can be read directly
 	Model     UnitModel
+	modelMu   sync.RWMutex
 	Command   *exec.Cmd
+	Process   *os.Process
 	LogFile   *os.File
 	StartedAt time.Time
 	Cancel    func()
+	Adopted   bool
+
+	// env is the resolved env of the unit process, used for its health checks and hooks
+	env         []string
+	stopTimeout time.Duration
+	health      atomic.Uint32
+	stop        atomic.Pointer[unitStop]
+	// restartPending is set while the watcher waits for the restart delay,
+	// a stop clears it to cancel the restart
+	restartPending atomic.Bool
+	done           chan struct{}
+	exitHandled    chan struct{}
+	exitCode       int
+	exitSignal     syscall.Signal
+
+	// stdin is the write end of the unit stdin pipe or the master of its
+	// pseudo-terminal, closed once the process exits
+	stdinMu sync.Mutex
+	stdin   *os.File
 }
 
 func (u *Unit) Status() UnitStatus {
-	if u.Cancel == nil {
+	if u.Cancel == nil || u.stop.Load() != nil {
 		return UnitStatusStopped
 	}
 
-	if u.Command.ProcessState == nil {
+	select {
+	case <-u.done:
+	default:
 		return UnitStatusRunning
 	}
 
-	switch u.Command.ProcessState.ExitCode() {
-	case -1:
-		return UnitStatusStopped
-	case 0:
+	if u.exitCode == 0 {
 		return UnitStatusExited
-	default:
-		return UnitStatusFailed
 	}
+
+	return UnitStatusFailed
+}
+
+func (u *Unit) Health() UnitHealth {
+	return UnitHealth(u.health.Load())
+}
+
+// ModelCopy returns a copy of the unit model
+func (u *Unit) ModelCopy() UnitModel {
+	u.modelMu.RLock()
+	defer u.modelMu.RUnlock()
+	return u.Model
+}
+
+// Name returns the current name of the unit
+func (u *Unit) Name() string {
+	u.modelMu.RLock()
+	defer u.modelMu.RUnlock()
+	return u.Model.Name
 }
 
 func (u *Unit) PB() *pb.Unit {
-	var pid int32
+	var (
+		pid    int32
+		health UnitHealth
+	)
 
 	unitStatus := u.Status()
+	model := u.ModelCopy()
 
 	if unitStatus == UnitStatusRunning {
-		pid = int32(u.Command.Process.Pid)
+		pid = int32(u.Process.Pid)
+		health = u.Health()
 	}
 
 	return &pb.Unit{
 		Id:            u.Model.ID,
-		Name:          u.Model.Name,
+		Name:          model.Name,
 		Pid:           pid,
 		Status:        uint32(unitStatus),
-		RestartsCount: u.Model.RestartsCount,
+		RestartsCount: model.RestartsCount,
 		StartedAt:     u.StartedAt.Unix(),
+		OwnerUid:      u.Model.OwnerUID,
+		Health:        health.String(),
+		Labels:        model.Labels,
 	}
 }
 
-func (u *Unit) Stop() {
-	if u.Cancel == nil {
+// Done is closed once the unit process exits
+func (u *Unit) Done() <-chan struct{} {
+	return u.done
+}
+
+// Stop sends the stop signal to the unit process group and waits for the
+// process to exit. The group is killed if it's still running after the stop timeout.
+// The reason and the stopping caller are recorded in the unit history before Stop returns
+func (u *Unit) Stop(reason string, by string) {
+	// Status is read concurrently, so Cancel is left set and the stop is
+	// recorded in stop, with its reason for the exit watcher
+	if u.Cancel == nil || !u.stop.CompareAndSwap(nil, &unitStop{reason: reason, by: by}) {
 		return
 	}
 
 	u.Cancel()
-	u.Cancel = nil
+
+	select {
+	case <-u.done:
+	case <-time.After(u.stopTimeout):
+		syscall.Kill(-u.Process.Pid, syscall.SIGKILL)
+		u.Process.Kill()
+		<-u.done
+	}
+
+	<-u.exitHandled
+}
+
+func (u *Unit) exit(exitCode int, exitSignal syscall.Signal) {
+	u.exitCode = exitCode
+	u.exitSignal = exitSignal
+	close(u.done)
 }
@@ -0,0 +1,307 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+// UnitUpdateFields are the update mask paths, in the order of the update diff
+var UnitUpdateFields = []string{
+	"name",
+	"bin",
+	"args",
+	"cwd",
+	"env",
+	"env_mode",
+	"env_allow",
+	"env_files",
+	"labels",
+	"user",
+	"group",
+	"groups",
+	"restart_policy",
+	"restart_delay_ms",
+	"stop_signal",
+	"stop_timeout_ms",
+	"health_cmd",
+	"health_interval_ms",
+	"hooks",
+	"stdin",
+	"tty",
+	"strip_ansi",
+}
+
+// unitModelFields are the fields of UnitModel set by the update mask paths
+var unitModelFields = map[string]string{
+	"name":               "Name",
+	"bin":                "Bin",
+	"args":               "Args",
+	"cwd":                "CWD",
+	"env":                "Env",
+	"env_mode":           "EnvMode",
+	"env_allow":          "EnvAllow",
+	"env_files":          "EnvFiles",
+	"labels":             "Labels",
+	"user":               "User",
+	"group":              "Group",
+	"groups":             "Groups",
+	"restart_policy":     "RestartPolicy",
+	"restart_delay_ms":   "RestartDelay",
+	"stop_signal":        "StopSignal",
+	"stop_timeout_ms":    "StopTimeout",
+	"health_cmd":         "HealthCmd",
+	"health_interval_ms": "HealthInterval",
+	"hooks":              "Hooks",
+	"stdin":              "Stdin",
+	"tty":                "TTY",
+	"strip_ansi":         "StripANSI",
+}
+
+// updateMaskPaths returns the fields changed by the request. Requests without
+// a mask change a non-empty name and merge env, as Update did before the mask
+func updateMaskPaths(request *pb.UpdateRequst) ([]string, bool) {
+	if request.UpdateMask != nil {
+		return request.UpdateMask.Paths, request.MergeEnv
+	}
+
+	var paths []string
+
+	if len(request.Name) > 0 {
+		paths = append(paths, "name")
+	}
+
+	if len(request.Env) > 0 {
+		paths = append(paths, "env")
+	}
+
+	return paths, true
+}
+
+// applyUnitUpdate sets the fields of the model listed in paths from the request
+func applyUnitUpdate(model *UnitModel, request *pb.UpdateRequst, paths []string, mergeEnv bool) error {
+	for _, field := range paths {
+		switch field {
+		case "name":
+			if len(request.Name) == 0 {
+				return errors.New("name can't be empty")
+			}
+
+			model.Name = request.Name
+		case "bin":
+			if len(request.Bin) == 0 {
+				return errors.New("bin can't be empty")
+			}
+
+			model.Bin = request.Bin
+		case "args":
+			model.Args = request.Args
+		case "cwd":
+			model.CWD = request.Cwd
+		case "env":
+			if mergeEnv {
+				model.Env = updateEnv(model.Env, request.Env)
+			} else {
+				model.Env = request.Env
+			}
+		case "env_mode":
+			if len(request.EnvMode) > 0 {
+				if _, err := ParseEnvMode(request.EnvMode); err != nil {
+					return err
+				}
+			}
+
+			model.EnvMode = request.EnvMode
+		case "env_allow":
+			model.EnvAllow = request.EnvAllow
+		case "env_files":
+			model.EnvFiles = request.EnvFiles
+		case "labels":
+			if err := ValidateLabels(request.Labels); err != nil {
+				return err
+			}
+
+			if request.MergeLabels {
+				model.Labels = mergeLabels(model.Labels, request.Labels)
+			} else {
+				model.Labels = request.Labels
+			}
+		case "user":
+			model.User = request.User
+		case "group":
+			model.Group = request.Group
+		case "groups":
+			model.Groups = request.Groups
+		case "restart_policy":
+			if len(request.RestartPolicy) > 0 {
+				if _, err := ParseRestartPolicy(request.RestartPolicy); err != nil {
+					return err
+				}
+			}
+
+			model.RestartPolicy = request.RestartPolicy
+		case "restart_delay_ms":
+			model.RestartDelay = time.Duration(request.RestartDelayMs) * time.Millisecond
+		case "stop_signal":
+			if len(request.StopSignal) > 0 {
+				if _, err := ParseSignal(request.StopSignal); err != nil {
+					return err
+				}
+			}
+
+			model.StopSignal = request.StopSignal
+		case "stop_timeout_ms":
+			model.StopTimeout = time.Duration(request.StopTimeoutMs) * time.Millisecond
+		case "health_cmd":
+			model.HealthCmd = request.HealthCmd
+		case "health_interval_ms":
+			model.HealthInterval = time.Duration(request.HealthIntervalMs) * time.Millisecond
+		case "hooks":
+			hooks := make([]HookConfig, len(request.Hooks))
+
+			for i, hook := range request.Hooks {
+				hookConfig, err := ParseUnitHook(hook)
+
+				if err != nil {
+					return err
+				}
+
+				hooks[i] = hookConfig
+			}
+
+			model.Hooks = hooks
+		case "stdin":
+			model.Stdin = request.Stdin
+		case "tty":
+			model.TTY = request.Tty
+		case "strip_ansi":
+			model.StripANSI = request.StripAnsi
+		default:
+			return fmt.Errorf("unknown update field %q", field)
+		}
+	}
+
+	return nil
+}
+
+// UpdateUnitModel returns the model with the update request applied and the
+// changed fields, it doesn't save or restart the unit
+func UpdateUnitModel(model UnitModel, request *pb.UpdateRequst) (UnitModel, []*pb.UnitFieldChange, error) {
+	paths, mergeEnv := updateMaskPaths(request)
+	updatedModel := model
+
+	if err := applyUnitUpdate(&updatedModel, request, paths, mergeEnv); err != nil {
+		return model, nil, err
+	}
+
+	return updatedModel, unitChanges(&model, &updatedModel), nil
+}
+
+// unitFieldValue formats a field of the model for the update diff. Env
+// values that look like secrets are masked
+func unitFieldValue(model *UnitModel, field string) string {
+	switch field {
+	case "name":
+		return model.Name
+	case "bin":
+		return model.Bin
+	case "args":
+		return strings.Join(model.Args, " ")
+	case "cwd":
+		return model.CWD
+	case "env":
+		return strings.Join(sortedEnv(MaskEnv(model.Env)), " ")
+	case "env_mode":
+		return model.EnvMode
+	case "env_allow":
+		return strings.Join(model.EnvAllow, " ")
+	case "env_files":
+		return strings.Join(model.EnvFiles, " ")
+	case "labels":
+		return strings.Join(FormatLabels(model.Labels), " ")
+	case "user":
+		return model.User
+	case "group":
+		return model.Group
+	case "groups":
+		return strings.Join(model.Groups, " ")
+	case "restart_policy":
+		return model.RestartPolicy
+	case "restart_delay_ms":
+		return formatUnitFieldDuration(model.RestartDelay)
+	case "stop_signal":
+		return model.StopSignal
+	case "stop_timeout_ms":
+		return formatUnitFieldDuration(model.StopTimeout)
+	case "health_cmd":
+		return model.HealthCmd
+	case "health_interval_ms":
+		return formatUnitFieldDuration(model.HealthInterval)
+	case "hooks":
+		hooks := make([]string, len(model.Hooks))
+
+		for i, hook := range model.Hooks {
+			hooks[i] = hook.String()
+		}
+
+		return strings.Join(hooks, " ")
+	case "stdin":
+		return formatUnitFieldBool(model.Stdin)
+	case "tty":
+		return formatUnitFieldBool(model.TTY)
+	case "strip_ansi":
+		return formatUnitFieldBool(model.StripANSI)
+	default:
+		return ""
+	}
+}
+
+// formatUnitFieldDuration leaves the zero duration empty, it means the daemon default
+func formatUnitFieldDuration(duration time.Duration) string {
+	if duration == 0 {
+		return ""
+	}
+
+	return duration.String()
+}
+
+func formatUnitFieldBool(value bool) string {
+	if !value {
+		return ""
+	}
+
+	return "true"
+}
+
+func sortedEnv(env []string) []string {
+	sorted := slices.Clone(env)
+	slices.Sort(sorted)
+	return sorted
+}
+
+// unitChanges returns the fields that differ between the models, in the
+// order of UnitUpdateFields. Env is compared before masking, so a changed
+// secret value is listed too
+func unitChanges(oldModel *UnitModel, newModel *UnitModel) []*pb.UnitFieldChange {
+	var changes []*pb.UnitFieldChange
+
+	for _, field := range UnitUpdateFields {
+		oldValue := unitFieldValue(oldModel, field)
+		newValue := unitFieldValue(newModel, field)
+		changed := oldValue != newValue
+
+		if field == "env" {
+			changed = !slices.Equal(sortedEnv(oldModel.Env), sortedEnv(newModel.Env))
+		}
+
+		if changed {
+			changes = append(changes, &pb.UnitFieldChange{Field: field, Old: oldValue, New: newValue})
+		}
+	}
+
+	return changes
+}
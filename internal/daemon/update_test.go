@@ -0,0 +1,224 @@
+package daemon
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+func testUpdateModel() UnitModel {
+	return UnitModel{
+		ID:         1,
+		Name:       "web",
+		Bin:        "./server",
+		Args:       []string{"--port", "80"},
+		Env:        []string{"A=1", "B=2"},
+		Labels:     map[string]string{"app": "web", "tier": "front"},
+		StopSignal: "SIGTERM",
+	}
+}
+
+func updateMask(paths ...string) *fieldmaskpb.FieldMask {
+	return &fieldmaskpb.FieldMask{Paths: paths}
+}
+
+func TestUpdateUnitModel(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *pb.UpdateRequst
+		update  func(model *UnitModel)
+		changes []string
+		err     string
+	}{
+		{
+			name:    "no mask changes the name and merges env",
+			request: &pb.UpdateRequst{Name: "api", Bin: "./other", Env: []string{"B=3", "C=4"}},
+			update: func(model *UnitModel) {
+				model.Name = "api"
+				model.Env = []string{"A=1", "B=3", "C=4"}
+			},
+			changes: []string{"name", "env"},
+		},
+		{
+			name:    "no mask keeps an empty name",
+			request: &pb.UpdateRequst{Env: []string{"A="}},
+			update: func(model *UnitModel) {
+				model.Env = []string{"B=2"}
+			},
+			changes: []string{"env"},
+		},
+		{
+			name:    "mask replaces env",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("env"), Env: []string{"C=3"}},
+			update: func(model *UnitModel) {
+				model.Env = []string{"C=3"}
+			},
+			changes: []string{"env"},
+		},
+		{
+			name:    "mask with merge_env deletes empty values",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("env"), MergeEnv: true, Env: []string{"A=", "C=3"}},
+			update: func(model *UnitModel) {
+				model.Env = []string{"B=2", "C=3"}
+			},
+			changes: []string{"env"},
+		},
+		{
+			name:    "mask ignores the fields not listed",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("args"), Name: "api", Env: []string{"C=3"}},
+			update: func(model *UnitModel) {
+				model.Args = nil
+			},
+			changes: []string{"args"},
+		},
+		{
+			name:    "merge_labels deletes empty values",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("labels"), MergeLabels: true, Labels: map[string]string{"tier": "", "env": "prod"}},
+			update: func(model *UnitModel) {
+				model.Labels = map[string]string{"app": "web", "env": "prod"}
+			},
+			changes: []string{"labels"},
+		},
+		{
+			name:    "labels are replaced without merge_labels",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("labels"), Labels: map[string]string{"env": "prod"}},
+			update: func(model *UnitModel) {
+				model.Labels = map[string]string{"env": "prod"}
+			},
+			changes: []string{"labels"},
+		},
+		{
+			name:    "durations",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("stop_timeout_ms", "restart_delay_ms"), StopTimeoutMs: 3000},
+			update: func(model *UnitModel) {
+				model.StopTimeout = 3 * time.Second
+			},
+			changes: []string{"stop_timeout_ms"},
+		},
+		{
+			name:    "no changes",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("name", "stop_signal"), Name: "web", StopSignal: "SIGTERM"},
+			update:  func(model *UnitModel) {},
+		},
+		{
+			name:    "unknown field",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("name", "command"), Name: "api"},
+			err:     `unknown update field "command"`,
+		},
+		{
+			name:    "empty name in the mask",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("name")},
+			err:     "name can't be empty",
+		},
+		{
+			name:    "empty bin in the mask",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("bin")},
+			err:     "bin can't be empty",
+		},
+		{
+			name:    "invalid stop signal",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("stop_signal"), StopSignal: "SIGNOPE"},
+			err:     "signal",
+		},
+		{
+			name:    "invalid label key",
+			request: &pb.UpdateRequst{UpdateMask: updateMask("labels"), Labels: map[string]string{"bad key": "x"}},
+			err:     `label key "bad key"`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			model := testUpdateModel()
+			updatedModel, changes, err := UpdateUnitModel(model, test.request)
+
+			if !reflect.DeepEqual(model, testUpdateModel()) {
+				t.Fatalf("the update modified the original model: %+v", model)
+			}
+
+			if len(test.err) > 0 {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("err %v, expected %q", err, test.err)
+				}
+
+				if !reflect.DeepEqual(updatedModel, model) || changes != nil {
+					t.Fatalf("a failed update returned %+v and %v", updatedModel, changes)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedModel := testUpdateModel()
+			test.update(&expectedModel)
+			updatedModel.Env = sortedEnv(updatedModel.Env)
+
+			if !reflect.DeepEqual(updatedModel, expectedModel) {
+				t.Fatalf("model %+v, expected %+v", updatedModel, expectedModel)
+			}
+
+			var changedFields []string
+
+			for _, change := range changes {
+				changedFields = append(changedFields, change.Field)
+			}
+
+			if !slices.Equal(changedFields, test.changes) {
+				t.Fatalf("changed fields %v, expected %v", changedFields, test.changes)
+			}
+		})
+	}
+}
+
+func TestUpdateUnitModelMasksSecrets(t *testing.T) {
+	model := testUpdateModel()
+	model.Env = []string{"DB_PASSWORD=old-secret", "PORT=80"}
+
+	_, changes, err := UpdateUnitModel(model, &pb.UpdateRequst{
+		UpdateMask: updateMask("env"),
+		MergeEnv:   true,
+		Env:        []string{"DB_PASSWORD=new-secret"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(changes) != 1 || changes[0].Field != "env" {
+		t.Fatalf("changes %v, expected the changed secret to be listed", changes)
+	}
+
+	expected := "DB_PASSWORD=" + maskedEnvValue + " PORT=80"
+
+	if changes[0].Old != expected || changes[0].New != expected {
+		t.Fatalf("env change %q -> %q, expected the masked %q", changes[0].Old, changes[0].New, expected)
+	}
+}
+
+func TestUnitModelFields(t *testing.T) {
+	modelType := reflect.TypeOf(UnitModel{})
+
+	for _, field := range UnitUpdateFields {
+		modelField, ok := unitModelFields[field]
+
+		if !ok {
+			t.Fatalf("update field %q has no model field", field)
+		}
+
+		if _, ok := modelType.FieldByName(modelField); !ok {
+			t.Fatalf("update field %q is saved as the missing model field %s", field, modelField)
+		}
+	}
+
+	if len(unitModelFields) != len(UnitUpdateFields) {
+		t.Fatalf("%d model fields, expected %d", len(unitModelFields), len(UnitUpdateFields))
+	}
+}
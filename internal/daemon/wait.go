@@ -0,0 +1,206 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	WaitRunning = "running"
+	WaitExited  = "exited"
+	WaitHealthy = "healthy"
+	WaitStopped = "stopped"
+)
+
+// unitStateMeets reports whether the unit state meets the running, healthy or stopped condition
+func unitStateMeets(pbUnit *pb.Unit, condition string) bool {
+	unitStatus := UnitStatus(pbUnit.Status)
+
+	switch condition {
+	case WaitRunning:
+		return unitStatus == UnitStatusRunning
+	case WaitHealthy:
+		return unitStatus == UnitStatusRunning && pbUnit.Health == UnitHealthHealthy.String()
+	case WaitStopped:
+		return unitStatus == UnitStatusStopped
+	default:
+		return false
+	}
+}
+
+// unitWaitResult returns the result of the wait if the unit meets the condition.
+// Exited is met by any unit whose process is gone, with its last exit code
+func unitWaitResult(unit *Unit, condition string) (*pb.WaitResult, bool) {
+	result := &pb.WaitResult{UnitId: unit.Model.ID, Unit: unit.PB()}
+
+	if condition != WaitExited {
+		return result, unitStateMeets(result.Unit, condition)
+	}
+
+	select {
+	case <-unit.Done():
+	default:
+		return nil, false
+	}
+
+	result.ExitCode = int32(unit.exitCode)
+
+	if unit.exitSignal != 0 {
+		result.Signal = SignalName(unit.exitSignal)
+	}
+
+	return result, true
+}
+
+// eventWaitResult is unitWaitResult for the unit state published with the
+// event. The exit code of the exited condition comes from the exit event
+func eventWaitResult(event *pb.Event, condition string) (*pb.WaitResult, bool) {
+	result := &pb.WaitResult{UnitId: event.Unit.Id, Unit: event.Unit}
+
+	if condition != WaitExited {
+		return result, unitStateMeets(result.Unit, condition)
+	}
+
+	unitEvent := event.UnitEvent
+
+	if unitEvent == nil || (unitEvent.Type != UnitEventExited && unitEvent.Type != UnitEventStopped) {
+		return nil, false
+	}
+
+	result.ExitCode = unitEvent.ExitCode
+	result.Signal = unitEvent.Signal
+	return result, true
+}
+
+// Wait blocks until the units meet the condition. It subscribes to the unit
+// events before reading the unit states, so no transition is missed
+func (s *DaemonServer) Wait(ctx context.Context, request *pb.WaitRequest) (*pb.WaitResponse, error) {
+	switch request.Condition {
+	case WaitRunning, WaitExited, WaitHealthy, WaitStopped:
+	default:
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"unknown wait condition %q, expected running, exited, healthy or stopped",
+			request.Condition,
+		)
+	}
+
+	if len(request.UnitIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "specify the units to wait for")
+	}
+
+	_, subscriber, err := s.events.Subscribe(0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer s.events.Unsubscribe(subscriber)
+
+	caller := callerFromContext(ctx)
+	results := make(map[uint64]*pb.WaitResult, len(request.UnitIds))
+	pending := make(map[uint64]struct{})
+
+	for _, unitID := range request.UnitIds {
+		unit := s.getUnit(caller, unitID)
+
+		if unit == nil {
+			return nil, status.Errorf(codes.NotFound, "unit %d not found", unitID)
+		}
+
+		if request.Condition == WaitHealthy && len(unit.ModelCopy().HealthCmd) == 0 {
+			return nil, status.Errorf(
+				codes.FailedPrecondition,
+				"unit %s (%d) has no health command",
+				unit.Name(),
+				unit.Model.ID,
+			)
+		}
+
+		if result, ok := unitWaitResult(unit, request.Condition); ok {
+			results[unitID] = result
+			continue
+		}
+
+		pending[unitID] = struct{}{}
+	}
+
+	var timeout <-chan time.Time
+
+	if request.TimeoutMs > 0 {
+		timer := time.NewTimer(time.Duration(request.TimeoutMs) * time.Millisecond)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	for len(pending) > 0 {
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-timeout:
+			return nil, status.Errorf(
+				codes.DeadlineExceeded,
+				"timed out after %s waiting for units %s to be %s",
+				time.Duration(request.TimeoutMs)*time.Millisecond,
+				formatUnitIDs(pending),
+				request.Condition,
+			)
+		case event, ok := <-subscriber.events:
+			if !ok {
+				if subscriber.err != nil {
+					return nil, status.Error(codes.ResourceExhausted, subscriber.err.Error())
+				}
+
+				return nil, status.Error(codes.Unavailable, "daemon is stopping")
+			}
+
+			if event.Unit == nil {
+				continue
+			}
+
+			if _, ok := pending[event.Unit.Id]; !ok {
+				continue
+			}
+
+			if event.Type == EventDeleted {
+				return nil, status.Errorf(
+					codes.NotFound,
+					"unit %s (%d) was deleted",
+					event.Unit.Name,
+					event.Unit.Id,
+				)
+			}
+
+			if result, ok := eventWaitResult(event, request.Condition); ok {
+				results[event.Unit.Id] = result
+				delete(pending, event.Unit.Id)
+			}
+		}
+	}
+
+	response := pb.WaitResponse{Results: make([]*pb.WaitResult, len(request.UnitIds))}
+
+	for i, unitID := range request.UnitIds {
+		response.Results[i] = results[unitID]
+	}
+
+	return &response, nil
+}
+
+func formatUnitIDs(unitIDs map[uint64]struct{}) string {
+	formatted := make([]string, 0, len(unitIDs))
+
+	for _, unitID := range slices.Sorted(maps.Keys(unitIDs)) {
+		formatted = append(formatted, fmt.Sprint(unitID))
+	}
+
+	return strings.Join(formatted, ", ")
+}
@@ -5,7 +5,14 @@ import (
 	"path"
 )
 
-const PM0Dirname = ".pm0"
+const (
+	PM0Dirname     = ".pm0"
+	SocketFilename = "pm0.sock"
+	SocketEnvVar   = "PM0_SOCKET"
+	// SystemSocketFilepath is the socket of a root daemon, shared by the
+	// clients of every user
+	SystemSocketFilepath = "/run/pm0/pm0.sock"
+)
 
 func GetPM0Dirpath() (string, error) {
 	homeDir, err := os.UserHomeDir()
@@ -22,3 +29,46 @@ func GetPM0Dirpath() (string, error) {
 
 	return pm0Dirpath, nil
 }
+
+// GetSocketFilepath is the socket the clients connect to: the system socket
+// if a root daemon created it, the socket in the pm0 dir of the user otherwise
+func GetSocketFilepath() (string, error) {
+	if socketFilepath := os.Getenv(SocketEnvVar); len(socketFilepath) > 0 {
+		return socketFilepath, nil
+	}
+
+	if _, err := os.Stat(SystemSocketFilepath); err == nil {
+		return SystemSocketFilepath, nil
+	}
+
+	return getUserSocketFilepath()
+}
+
+// GetDaemonSocketFilepath is the socket the daemon listens on: the system
+// socket for a root daemon, so non-root clients can reach it, and the socket
+// in the pm0 dir for the daemons of other users
+func GetDaemonSocketFilepath() (string, error) {
+	if socketFilepath := os.Getenv(SocketEnvVar); len(socketFilepath) > 0 {
+		return socketFilepath, nil
+	}
+
+	if os.Geteuid() != 0 {
+		return getUserSocketFilepath()
+	}
+
+	if err := os.MkdirAll(path.Dir(SystemSocketFilepath), 0o755); err != nil {
+		return "", err
+	}
+
+	return SystemSocketFilepath, nil
+}
+
+func getUserSocketFilepath() (string, error) {
+	pm0Dirpath, err := GetPM0Dirpath()
+
+	if err != nil {
+		return "", err
+	}
+
+	return path.Join(pm0Dirpath, SocketFilename), nil
+}
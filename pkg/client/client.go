@@ -0,0 +1,447 @@
+package client
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"io"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/TrixiS/pm0/internal/utils"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+const (
+	maxRecvMessageSizeBytes = 8 * 1024 * 1024
+	unixAddressPrefix       = "unix://"
+	tcpAddressPrefix        = "tcp://"
+)
+
+type options struct {
+	address     string
+	useTLS      bool
+	tlsConfig   *tls.Config
+	token       string
+	dialOptions []grpc.DialOption
+}
+
+// Option configures the connection of New
+type Option func(*options)
+
+// WithAddress sets the daemon address: a unix socket path, unix://path,
+// tcp://host:port or host:port. The default is the socket of pm0, the one of
+// PM0_SOCKET if it's set
+func WithAddress(address string) Option {
+	return func(o *options) {
+		o.address = address
+	}
+}
+
+// WithTLS connects with TLS, a nil config verifies the daemon with the system
+// roots
+func WithTLS(config *tls.Config) Option {
+	return func(o *options) {
+		o.useTLS = true
+		o.tlsConfig = config
+	}
+}
+
+// WithToken authenticates the calls with a daemon token, it requires TLS
+func WithToken(token string) Option {
+	return func(o *options) {
+		o.token = token
+	}
+}
+
+// WithDialOptions adds grpc dial options, they are applied after the ones of
+// the other options
+func WithDialOptions(dialOptions ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, dialOptions...)
+	}
+}
+
+// target converts an address to a grpc target like the pm0 --address flag
+func target(address string) string {
+	if strings.HasPrefix(address, "/") {
+		return unixAddressPrefix + address
+	}
+
+	if tcpAddress, ok := strings.CutPrefix(address, tcpAddressPrefix); ok {
+		return tcpAddress
+	}
+
+	return address
+}
+
+type tokenCredentials struct {
+	token string
+}
+
+func (c tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + c.token}, nil
+}
+
+func (tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// Client is a connection to the daemon, it's safe for concurrent use
+type Client struct {
+	conn    *grpc.ClientConn
+	service pb.ProcessServiceClient
+}
+
+// New creates a client, the connection is established on the first call
+func New(opts ...Option) (*Client, error) {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	if len(o.address) == 0 {
+		socketFilepath, err := utils.GetSocketFilepath()
+
+		if err != nil {
+			return nil, err
+		}
+
+		o.address = socketFilepath
+	}
+
+	dialOptions := []grpc.DialOption{
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(maxRecvMessageSizeBytes)),
+	}
+
+	if o.useTLS {
+		tlsConfig := o.tlsConfig
+
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		dialOptions = append(dialOptions, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	}
+
+	if len(o.token) > 0 {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(tokenCredentials{o.token}))
+	}
+
+	conn, err := grpc.NewClient(target(o.address), append(dialOptions, o.dialOptions...)...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{conn: conn, service: pb.NewProcessServiceClient(conn)}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// List returns the units ordered by id
+func (c *Client) List(ctx context.Context) ([]Unit, error) {
+	response, err := c.service.List(ctx, &emptypb.Empty{})
+
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+
+	slices.SortFunc(response.Units, func(a *pb.Unit, b *pb.Unit) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	units := make([]Unit, len(response.Units))
+
+	for i, unit := range response.Units {
+		units[i] = unitFromPB(unit)
+	}
+
+	return units, nil
+}
+
+func (c *Client) Show(ctx context.Context, unitID uint64) (*UnitDetails, error) {
+	response, err := c.service.Show(ctx, &pb.ShowRequest{UnitId: unitID})
+
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+
+	return unitDetailsFromPB(response), nil
+}
+
+// Start creates a unit from the spec and starts it
+func (c *Client) Start(ctx context.Context, spec UnitSpec) (StartResult, error) {
+	response, err := c.service.Start(ctx, startRequestFromSpec(&spec))
+
+	if err != nil {
+		return StartResult{}, convertError(ctx, err)
+	}
+
+	return StartResult{ID: response.Id, Name: response.Name, PID: int(response.Pid)}, nil
+}
+
+// Update changes the fields of the unit listed in options.Fields
+func (c *Client) Update(ctx context.Context, unitID uint64, options UpdateOptions) (*UpdateResult, error) {
+	request := updateRequestFromOptions(unitID, &options)
+	request.UpdateMask = &fieldmaskpb.FieldMask{Paths: options.Fields}
+	response, err := c.service.Update(ctx, request)
+
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+
+	result := &UpdateResult{
+		Changes: make([]FieldChange, len(response.Changes)),
+		Unit:    unitFromPB(response.Unit),
+	}
+
+	for i, change := range response.Changes {
+		result.Changes[i] = FieldChange{Field: change.Field, Old: change.Old, New: change.New}
+	}
+
+	if len(response.RestartError) > 0 {
+		result.RestartErr = unitError(unitID, 0, response.RestartError)
+	}
+
+	return result, nil
+}
+
+// Wait blocks until every unit meets the condition, one of the Wait
+// constants. Cancel ctx or set its deadline to stop waiting
+func (c *Client) Wait(ctx context.Context, condition string, unitIDs ...uint64) ([]WaitResult, error) {
+	response, err := c.service.Wait(ctx, &pb.WaitRequest{UnitIds: unitIDs, Condition: condition})
+
+	if err != nil {
+		return nil, convertError(ctx, err)
+	}
+
+	results := make([]WaitResult, len(response.Results))
+
+	for i, result := range response.Results {
+		results[i] = WaitResult{
+			Unit:     unitFromPB(result.Unit),
+			ExitCode: int(result.ExitCode),
+			Signal:   result.Signal,
+		}
+	}
+
+	return results, nil
+}
+
+// Send writes data to the stdin of a unit started with UnitSpec.Stdin
+func (c *Client) Send(ctx context.Context, unitID uint64, data []byte) error {
+	_, err := c.service.Send(ctx, &pb.SendRequest{UnitId: unitID, Data: data})
+	return convertError(ctx, err)
+}
+
+// StartExisting starts the stopped units
+func (c *Client) StartExisting(ctx context.Context, unitIDs ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.StartExisting(ctx, &pb.StopRequest{UnitIds: unitIDs})
+	})
+}
+
+func (c *Client) Stop(ctx context.Context, unitIDs ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.Stop(ctx, &pb.StopRequest{UnitIds: unitIDs})
+	})
+}
+
+// StopAll stops every unit of the caller except the listed ones
+func (c *Client) StopAll(ctx context.Context, except ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.StopAll(ctx, &pb.ExceptRequest{UnitIds: except})
+	})
+}
+
+// Restart restarts the units, the stopped ones are started
+func (c *Client) Restart(ctx context.Context, unitIDs ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.Restart(ctx, &pb.StopRequest{UnitIds: unitIDs})
+	})
+}
+
+func (c *Client) RestartAll(ctx context.Context, except ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.RestartAll(ctx, &pb.ExceptRequest{UnitIds: except})
+	})
+}
+
+// Delete stops and deletes the units
+func (c *Client) Delete(ctx context.Context, unitIDs ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.Delete(ctx, &pb.StopRequest{UnitIds: unitIDs})
+	})
+}
+
+func (c *Client) DeleteAll(ctx context.Context, except ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.DeleteAll(ctx, &pb.ExceptRequest{UnitIds: except})
+	})
+}
+
+// Signal sends a signal like "SIGHUP" or "HUP" to the unit processes, or to
+// their process groups if group is set
+func (c *Client) Signal(ctx context.Context, signal string, group bool, unitIDs ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.Signal(ctx, &pb.SignalRequest{UnitIds: unitIDs, Signal: signal, Group: group})
+	})
+}
+
+func (c *Client) SignalAll(ctx context.Context, signal string, group bool, except ...uint64) iter.Seq2[UnitResult, error] {
+	return unitResults(ctx, func(ctx context.Context) (pb.ProcessService_StopClient, error) {
+		return c.service.SignalAll(ctx, &pb.SignalAllRequest{Except: except, Signal: signal, Group: group})
+	})
+}
+
+type LogsOptions struct {
+	Lines  uint64 // the number of the last lines, none if 0
+	Follow bool   // keep yielding the new lines until ctx is done
+}
+
+// Logs yields the last log lines of a unit in the order they were written,
+// then the new ones if options.Follow is set
+func (c *Client) Logs(ctx context.Context, unitID uint64, options LogsOptions) iter.Seq2[string, error] {
+	responses := stream(ctx, func(ctx context.Context) (grpc.ServerStreamingClient[pb.LogsResponse], error) {
+		return c.service.Logs(ctx, &pb.LogsRequest{UnitId: unitID, Lines: options.Lines, Follow: options.Follow})
+	}, func(response *pb.LogsResponse) (*pb.LogsResponse, bool) {
+		return response, true
+	})
+
+	return func(yield func(string, error) bool) {
+		// the daemon sends the last lines from the last one, until a flush
+		var tail []string
+		flushed := false
+
+		for response, err := range responses {
+			if err != nil {
+				yield("", err)
+				return
+			}
+
+			if flushed {
+				if !yield(response.Line, nil) {
+					return
+				}
+
+				continue
+			}
+
+			if !response.Flush {
+				tail = append(tail, response.Line)
+				continue
+			}
+
+			flushed = true
+
+			for i := len(tail) - 1; i >= 0; i-- {
+				if !yield(tail[i], nil) {
+					return
+				}
+			}
+		}
+	}
+}
+
+type EventsOptions struct {
+	// Selectors filter the unit events like the ones of pm0 events, the
+	// daemon events are always yielded
+	Selectors []string
+	// Since resumes after the event with the sequence number
+	Since uint64
+}
+
+// Events yields the events until ctx is done
+func (c *Client) Events(ctx context.Context, options EventsOptions) iter.Seq2[Event, error] {
+	return stream(ctx, func(ctx context.Context) (grpc.ServerStreamingClient[pb.Event], error) {
+		return c.service.Events(ctx, &pb.EventsRequest{Selectors: options.Selectors, Since: options.Since})
+	}, func(event *pb.Event) (Event, bool) {
+		return eventFromPB(event), true
+	})
+}
+
+// CollectResults collects the results of a unit action. The error is the one
+// of the call, the errors of the units are in the results
+func CollectResults(results iter.Seq2[UnitResult, error]) (UnitResults, error) {
+	var collected UnitResults
+
+	for result, err := range results {
+		if err != nil {
+			return collected, err
+		}
+
+		collected = append(collected, result)
+	}
+
+	return collected, nil
+}
+
+func unitResults(
+	ctx context.Context,
+	call func(ctx context.Context) (pb.ProcessService_StopClient, error),
+) iter.Seq2[UnitResult, error] {
+	return stream(ctx, call, func(response *pb.StopResponse) (UnitResult, bool) {
+		return UnitResult{
+			UnitID: response.UnitId,
+			Unit:   optionalUnitFromPB(response.Unit),
+			Err:    unitError(response.UnitId, response.Code, response.Error),
+		}, true
+	})
+}
+
+// stream calls the streaming RPC when the iteration starts and yields the
+// converted responses, the ones convert skips aren't yielded. An error ends
+// the iteration, breaking out of the loop cancels the call
+func stream[T any, R any](
+	ctx context.Context,
+	call func(ctx context.Context) (grpc.ServerStreamingClient[R], error),
+	convert func(response *R) (T, bool),
+) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		responses, err := call(ctx)
+
+		if err != nil {
+			yield(zero, convertError(ctx, err))
+			return
+		}
+
+		for {
+			response, err := responses.Recv()
+
+			if err != nil {
+				if err != io.EOF {
+					yield(zero, convertError(ctx, err))
+				}
+
+				return
+			}
+
+			value, ok := convert(response)
+
+			if !ok {
+				continue
+			}
+
+			if !yield(value, nil) {
+				return
+			}
+		}
+	}
+}
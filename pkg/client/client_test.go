@@ -0,0 +1,520 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/TrixiS/pm0/pkg/client"
+	"github.com/TrixiS/pm0/pkg/client/clienttest"
+	"github.com/asdine/storm/v3"
+	"google.golang.org/grpc"
+)
+
+const (
+	testTimeout = 5 * time.Second
+	missingUnit = uint64(999)
+)
+
+// backend is a daemon the scenarios run against, the fake and the real one
+// should behave the same for them
+type backend struct {
+	name      string
+	newDaemon func(t *testing.T) *testDaemon
+}
+
+type testDaemon struct {
+	client *client.Client
+	// startLogger starts a unit, writeLogs adds the lines to its log
+	startLogger func(t *testing.T) uint64
+	writeLogs   func(unitID uint64, lines ...string) error
+}
+
+var backends = []backend{
+	{"fake", newFakeDaemon},
+	{"daemon", newDaemon},
+}
+
+func newFakeDaemon(t *testing.T) *testDaemon {
+	server := clienttest.NewServer()
+	t.Cleanup(server.Close)
+
+	c, err := server.Client()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		c.Close()
+	})
+
+	return &testDaemon{
+		client: c,
+		startLogger: func(t *testing.T) uint64 {
+			return startSleep(t, c, "logger", nil)
+		},
+		writeLogs: server.AppendLogs,
+	}
+}
+
+// newDaemon connects a client to a daemon server listening on a loopback
+// address with its data in a temporary directory
+func newDaemon(t *testing.T) *testDaemon {
+	dataDirpath := t.TempDir()
+	config := daemon.DefaultConfig(dataDirpath, path.Join(dataDirpath, "pm0.sock"))
+
+	if err := os.MkdirAll(config.LogsDirpath(), 0o700); err != nil {
+		t.Fatal(err)
+	}
+
+	dbFilepath := path.Join(dataDirpath, config.DBFile)
+	server := daemon.NewDaemonServer(daemon.DaemonServerOptions{
+		LogsDirpath: config.LogsDirpath(),
+		DBFactory: func() *storm.DB {
+			db, err := storm.Open(dbFilepath)
+
+			if err != nil {
+				panic(err)
+			}
+
+			return db
+		},
+		Config: config,
+	})
+
+	t.Cleanup(func() {
+		server.Shutdown(false)
+	})
+
+	// the callers of a loopback listener without tokens are admins
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authenticator := daemon.NewAuthenticator(nil, nil)
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(authenticator.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(authenticator.StreamInterceptor()),
+	)
+
+	pb.RegisterProcessServiceServer(grpcServer, server)
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	c, err := client.New(client.WithAddress(listener.Addr().String()))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		c.Close()
+	})
+
+	return &testDaemon{
+		client: c,
+		startLogger: func(t *testing.T) uint64 {
+			// cat writes the data sent to the unit to its log
+			result, err := c.Start(testContext(t), client.UnitSpec{Name: "logger", Bin: "cat", Stdin: true})
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			return result.ID
+		},
+		writeLogs: func(unitID uint64, lines ...string) error {
+			return c.Send(context.Background(), unitID, []byte(strings.Join(lines, "\n")+"\n"))
+		},
+	}
+}
+
+func testContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func startSleep(t *testing.T, c *client.Client, name string, labels map[string]string) uint64 {
+	t.Helper()
+
+	result, err := c.Start(testContext(t), client.UnitSpec{
+		Name:   name,
+		Bin:    "sleep",
+		Args:   []string{"30"},
+		Labels: labels,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return result.ID
+}
+
+func expectError(t *testing.T, err error, target *client.Error, message string) {
+	t.Helper()
+
+	if !errors.Is(err, target) {
+		t.Fatalf("err %v, expected code %s", err, target.Code)
+	}
+
+	if !strings.Contains(err.Error(), message) {
+		t.Fatalf("err %q, expected %q", err, message)
+	}
+}
+
+func TestClient(t *testing.T) {
+	scenarios := []struct {
+		name string
+		run  func(t *testing.T, b backend)
+	}{
+		{"start and wait", testStartAndWait},
+		{"stop results", testStopResults},
+		{"logs", testLogs},
+		{"events", testEvents},
+		{"not found", testNotFound},
+		{"invalid requests", testInvalidRequests},
+	}
+
+	for _, b := range backends {
+		t.Run(b.name, func(t *testing.T) {
+			for _, scenario := range scenarios {
+				t.Run(scenario.name, func(t *testing.T) {
+					scenario.run(t, b)
+				})
+			}
+		})
+	}
+}
+
+func testStartAndWait(t *testing.T, b backend) {
+	c := b.newDaemon(t).client
+	ctx := testContext(t)
+
+	result, err := c.Start(ctx, client.UnitSpec{
+		Name:   "web",
+		Bin:    "sleep",
+		Args:   []string{"30"},
+		Labels: map[string]string{"app": "web"},
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Name != "web" {
+		t.Fatalf("started unit %q, expected web", result.Name)
+	}
+
+	waited, err := c.Wait(ctx, client.WaitRunning, result.ID)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(waited) != 1 || waited[0].Unit.ID != result.ID || waited[0].Unit.Status != client.StatusRunning {
+		t.Fatalf("unexpected wait results %+v", waited)
+	}
+
+	if waited[0].Unit.PID == 0 || waited[0].Unit.PID != result.PID {
+		t.Fatalf("waited for pid %d, started %d", waited[0].Unit.PID, result.PID)
+	}
+
+	units, err := c.List(ctx)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(units) != 1 || units[0].Name != "web" || units[0].Labels["app"] != "web" {
+		t.Fatalf("unexpected units %+v", units)
+	}
+}
+
+func testStopResults(t *testing.T, b backend) {
+	c := b.newDaemon(t).client
+	ctx := testContext(t)
+	unitID := startSleep(t, c, "web", nil)
+
+	results, err := client.CollectResults(c.Stop(ctx, unitID, missingUnit))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("got %d results, expected 2", len(results))
+	}
+
+	for _, result := range results {
+		switch result.UnitID {
+		case unitID:
+			if result.Err != nil {
+				t.Fatal(result.Err)
+			}
+
+			if result.Unit == nil || result.Unit.Status != client.StatusStopped {
+				t.Fatalf("unexpected stopped unit %+v", result.Unit)
+			}
+		case missingUnit:
+			expectError(t, result.Err, client.ErrNotFound, "unit 999 not found")
+
+			var unitErr *client.Error
+
+			if !errors.As(result.Err, &unitErr) || unitErr.UnitID != missingUnit {
+				t.Fatalf("err %#v, expected the missing unit id", result.Err)
+			}
+
+			if result.Unit != nil {
+				t.Fatalf("the missing unit has a snapshot %+v", result.Unit)
+			}
+		default:
+			t.Fatalf("unexpected result for unit %d", result.UnitID)
+		}
+	}
+
+	if err := results.Err(); !errors.Is(err, client.ErrNotFound) {
+		t.Fatalf("joined err %v, expected the not found unit", err)
+	}
+
+	results, err = client.CollectResults(c.Stop(ctx, unitID))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectError(t, results.Err(), client.ErrFailedPrecondition, "is not running")
+
+	if results[0].Unit == nil {
+		t.Fatal("the not running unit has no snapshot")
+	}
+}
+
+func testLogs(t *testing.T, b backend) {
+	d := b.newDaemon(t)
+	c := d.client
+	unitID := d.startLogger(t)
+	expected := []string{"first line", "second line"}
+
+	if err := d.writeLogs(unitID, expected...); err != nil {
+		t.Fatal(err)
+	}
+
+	// the daemon writes the output to the log in the background
+	var lines []string
+	deadline := time.Now().Add(testTimeout)
+
+	for len(lines) < len(expected) && time.Now().Before(deadline) {
+		lines = nil
+
+		for line, err := range c.Logs(testContext(t), unitID, client.LogsOptions{Lines: 10}) {
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lines = append(lines, line)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if !slices.Equal(lines, expected) {
+		t.Fatalf("lines %q, expected %q", lines, expected)
+	}
+
+	var lastLines []string
+
+	for line, err := range c.Logs(testContext(t), unitID, client.LogsOptions{Lines: 1}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		lastLines = append(lastLines, line)
+	}
+
+	if !slices.Equal(lastLines, expected[1:]) {
+		t.Fatalf("last lines %q, expected %q", lastLines, expected[1:])
+	}
+
+	go func() {
+		// the following call starts before the new line is written
+		time.Sleep(200 * time.Millisecond)
+
+		if err := d.writeLogs(unitID, "new line"); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	var followed []string
+
+	for line, err := range c.Logs(testContext(t), unitID, client.LogsOptions{Lines: 1, Follow: true}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		followed = append(followed, line)
+
+		// breaking out of the loop ends the following call
+		if len(followed) == 2 {
+			break
+		}
+	}
+
+	if !slices.Equal(followed, []string{"second line", "new line"}) {
+		t.Fatalf("followed %q, expected the last line and the new one", followed)
+	}
+}
+
+func testEvents(t *testing.T, b backend) {
+	c := b.newDaemon(t).client
+	ctx := testContext(t)
+	selectors := []string{"app=events"}
+
+	eventsCtx, cancelEvents := context.WithCancel(ctx)
+	events := make(chan client.Event)
+
+	go func() {
+		defer close(events)
+
+		for event, err := range c.Events(eventsCtx, client.EventsOptions{Selectors: selectors}) {
+			if err != nil {
+				// the call ends with the canceled context
+				if eventsCtx.Err() == nil {
+					t.Error(err)
+				}
+
+				return
+			}
+
+			select {
+			case events <- event:
+			case <-eventsCtx.Done():
+				return
+			}
+		}
+	}()
+
+	defer func() {
+		cancelEvents()
+
+		for range events {
+		}
+	}()
+
+	time.Sleep(200 * time.Millisecond)
+
+	// the events of the units not selected are filtered out
+	startSleep(t, c, "not selected", nil)
+	unitID := startSleep(t, c, "events", map[string]string{"app": "events"})
+
+	if _, err := client.CollectResults(c.Stop(ctx, unitID)); err != nil {
+		t.Fatal(err)
+	}
+
+	var received []client.Event
+
+	for event := range events {
+		if event.Unit == nil || event.Unit.ID != unitID {
+			t.Fatalf("unexpected event %+v", event)
+		}
+
+		received = append(received, event)
+
+		if event.Type == client.EventStopped {
+			break
+		}
+	}
+
+	if len(received) != 2 || received[0].Type != client.EventStarted {
+		t.Fatalf("unexpected events %+v", received)
+	}
+
+	if received[1].UnitEvent == nil || received[1].UnitEvent.UnitID != unitID {
+		t.Fatalf("the stop event has no unit event %+v", received[1])
+	}
+
+	for event, err := range c.Events(ctx, client.EventsOptions{Selectors: selectors, Since: received[0].Seq}) {
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if event.Seq != received[1].Seq || event.Type != client.EventStopped {
+			t.Fatalf("resumed with %+v, expected the stop event", event)
+		}
+
+		break
+	}
+
+	for _, err := range c.Events(ctx, client.EventsOptions{Since: received[1].Seq + 100}) {
+		expectError(t, err, client.ErrOutOfRange, "is ahead of the daemon")
+		break
+	}
+}
+
+func testNotFound(t *testing.T, b backend) {
+	c := b.newDaemon(t).client
+	ctx := testContext(t)
+	const message = "unit 999 not found"
+
+	_, err := c.Show(ctx, missingUnit)
+	expectError(t, err, client.ErrNotFound, message)
+
+	_, err = c.Update(ctx, missingUnit, client.UpdateOptions{
+		Spec:   client.UnitSpec{Name: "renamed"},
+		Fields: []string{client.FieldName},
+	})
+
+	expectError(t, err, client.ErrNotFound, message)
+
+	_, err = c.Wait(ctx, client.WaitRunning, missingUnit)
+	expectError(t, err, client.ErrNotFound, message)
+
+	for _, err := range c.Logs(ctx, missingUnit, client.LogsOptions{}) {
+		expectError(t, err, client.ErrNotFound, message)
+	}
+
+	results, err := client.CollectResults(c.Delete(ctx, missingUnit))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != 1 || results[0].UnitID != missingUnit {
+		t.Fatalf("unexpected results %+v", results)
+	}
+
+	expectError(t, results[0].Err, client.ErrNotFound, message)
+}
+
+func testInvalidRequests(t *testing.T, b backend) {
+	c := b.newDaemon(t).client
+	ctx := testContext(t)
+	unitID := startSleep(t, c, "web", nil)
+
+	_, err := c.Start(ctx, client.UnitSpec{Name: "empty"})
+	expectError(t, err, client.ErrInvalidArgument, "bin can't be empty")
+
+	_, err = c.Start(ctx, client.UnitSpec{Name: "signal", Bin: "sleep", StopSignal: "SIGNOPE"})
+	expectError(t, err, client.ErrInvalidArgument, "signal")
+
+	_, err = c.Update(ctx, unitID, client.UpdateOptions{Fields: []string{"command"}})
+	expectError(t, err, client.ErrInvalidArgument, `unknown update field "command"`)
+
+	_, err = c.Wait(ctx, "done", unitID)
+	expectError(t, err, client.ErrInvalidArgument, "unknown wait condition")
+
+	_, err = c.Wait(ctx, client.WaitHealthy, unitID)
+	expectError(t, err, client.ErrFailedPrecondition, "has no health command")
+}
@@ -0,0 +1,833 @@
+// Package clienttest is an in-process fake of the pm0 daemon for the unit
+// tests of code using package client.
+//
+// The fake keeps the units in memory and doesn't run processes: started units
+// are running until they are stopped or a test calls Exit. It validates the
+// requests and returns the errors with the same codes as the daemon, so the
+// error handling of the tested code can be exercised:
+//
+//	server := clienttest.NewServer()
+//	defer server.Close()
+//
+//	c, err := server.Client()
+//
+// The fake serves the RPCs package client has methods for, the other RPCs
+// return codes.Unimplemented. Callers are treated like root, the daemon
+// policies aren't resolved and Show returns the policies of the spec.
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon"
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+	"github.com/TrixiS/pm0/pkg/client"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const (
+	bufferSize        = 1024 * 1024
+	showHistoryEvents = 5
+	firstPID          = 10000
+)
+
+type fakeUnit struct {
+	model      daemon.UnitModel
+	status     daemon.UnitStatus
+	pid        int32
+	startedAt  time.Time
+	health     string
+	exitCode   int32
+	exitSignal string
+	logs       []string
+	stdin      []byte
+	signals    []string
+	history    []*pb.UnitEvent
+}
+
+func (u *fakeUnit) pb() *pb.Unit {
+	unit := &pb.Unit{
+		Id:            u.model.ID,
+		Name:          u.model.Name,
+		Status:        uint32(u.status),
+		RestartsCount: u.model.RestartsCount,
+		StartedAt:     u.startedAt.Unix(),
+		OwnerUid:      u.model.OwnerUID,
+		Labels:        u.model.Labels,
+	}
+
+	if u.status == daemon.UnitStatusRunning {
+		unit.Pid = u.pid
+		unit.Health = u.health
+	}
+
+	return unit
+}
+
+// Server is the fake daemon, its methods are safe for concurrent use
+type Server struct {
+	mu        sync.Mutex
+	units     map[uint64]*fakeUnit
+	events    []*pb.Event
+	lastID    uint64
+	lastPID   int32
+	lastEvent uint64
+	// changed is closed and replaced on every change, the streams and Wait
+	// wait on it
+	changed chan struct{}
+
+	listener *bufconn.Listener
+	server   *grpc.Server
+}
+
+// NewServer starts a fake daemon listening in memory
+func NewServer() *Server {
+	s := &Server{
+		units:    make(map[uint64]*fakeUnit),
+		lastPID:  firstPID,
+		changed:  make(chan struct{}),
+		listener: bufconn.Listen(bufferSize),
+		server:   grpc.NewServer(),
+	}
+
+	pb.RegisterProcessServiceServer(s.server, &service{s: s})
+	go s.server.Serve(s.listener)
+	return s
+}
+
+// Client connects a client to the fake, the options like WithAddress and
+// WithTLS are overridden
+func (s *Server) Client(opts ...client.Option) (*client.Client, error) {
+	dialer := func(ctx context.Context, _ string) (net.Conn, error) {
+		return s.listener.DialContext(ctx)
+	}
+
+	return client.New(append(
+		opts,
+		client.WithAddress("passthrough:///bufnet"),
+		client.WithDialOptions(grpc.WithContextDialer(dialer)),
+	)...)
+}
+
+// Close stops the fake, the open streams end with an error
+func (s *Server) Close() {
+	s.server.Stop()
+}
+
+// AppendLogs appends lines to the unit log, following Logs streams get them
+func (s *Server) AppendLogs(unitID uint64, lines ...string) error {
+	return s.updateUnit(unitID, func(unit *fakeUnit) {
+		unit.logs = append(unit.logs, lines...)
+	})
+}
+
+// Exit ends the unit process with the exit code, like the process exited on
+// its own. The unit fails unless the code is 0
+func (s *Server) Exit(unitID uint64, exitCode int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unit := s.units[unitID]
+
+	if unit == nil {
+		return fmt.Errorf("unit %d not found", unitID)
+	}
+
+	if unit.status != daemon.UnitStatusRunning {
+		return fmt.Errorf("unit %s (%d) is not running", unit.model.Name, unitID)
+	}
+
+	s.exitUnit(unit, int32(exitCode), "", "", "")
+	return nil
+}
+
+// SetHealth sets the health of a running unit to client.HealthHealthy or
+// client.HealthUnhealthy
+func (s *Server) SetHealth(unitID uint64, health string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unit := s.units[unitID]
+
+	if unit == nil {
+		return fmt.Errorf("unit %d not found", unitID)
+	}
+
+	unit.health = health
+	s.recordEvent(unit, &pb.UnitEvent{Type: daemon.UnitEventHealth, Pid: unit.pid, Health: health})
+	return nil
+}
+
+// Stdin returns the data sent to the unit
+func (s *Server) Stdin(unitID uint64) []byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if unit := s.units[unitID]; unit != nil {
+		return slices.Clone(unit.stdin)
+	}
+
+	return nil
+}
+
+// Signals returns the names of the signals sent to the unit, like SIGHUP
+func (s *Server) Signals(unitID uint64) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if unit := s.units[unitID]; unit != nil {
+		return slices.Clone(unit.signals)
+	}
+
+	return nil
+}
+
+func (s *Server) updateUnit(unitID uint64, update func(unit *fakeUnit)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unit := s.units[unitID]
+
+	if unit == nil {
+		return fmt.Errorf("unit %d not found", unitID)
+	}
+
+	update(unit)
+	s.notify()
+	return nil
+}
+
+// notify wakes the streams and waits up, s.mu must be held
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// publish adds the event of the unit, s.mu must be held
+func (s *Server) publish(eventType string, unit *fakeUnit, unitEvent *pb.UnitEvent) {
+	s.lastEvent++
+
+	s.events = append(s.events, &pb.Event{
+		Seq:       s.lastEvent,
+		Time:      time.Now().UnixMilli(),
+		Type:      eventType,
+		Unit:      unit.pb(),
+		UnitEvent: unitEvent,
+	})
+
+	s.notify()
+}
+
+// recordEvent adds the event to the unit history and publishes it
+func (s *Server) recordEvent(unit *fakeUnit, unitEvent *pb.UnitEvent) {
+	unitEvent.Id = uint64(len(unit.history) + 1)
+	unitEvent.UnitId = unit.model.ID
+	unitEvent.Time = time.Now().UnixMilli()
+	unit.history = append(unit.history, unitEvent)
+	s.publish(unitEvent.Type, unit, unitEvent)
+}
+
+func (s *Server) startUnit(unit *fakeUnit) {
+	s.lastPID++
+	unit.status = daemon.UnitStatusRunning
+	unit.pid = s.lastPID
+	unit.startedAt = time.Now()
+	unit.health = ""
+	s.recordEvent(unit, &pb.UnitEvent{Type: daemon.UnitEventStarted, Pid: unit.pid})
+}
+
+func (s *Server) exitUnit(unit *fakeUnit, exitCode int32, signal string, reason string, by string) {
+	eventType := daemon.UnitEventExited
+	unit.status = daemon.UnitStatusExited
+
+	if len(reason) > 0 {
+		eventType = daemon.UnitEventStopped
+		unit.status = daemon.UnitStatusStopped
+	} else if exitCode != 0 {
+		unit.status = daemon.UnitStatusFailed
+	}
+
+	unit.exitCode = exitCode
+	unit.exitSignal = signal
+
+	s.recordEvent(unit, &pb.UnitEvent{
+		Type:       eventType,
+		Pid:        unit.pid,
+		ExitCode:   exitCode,
+		Signal:     signal,
+		DurationMs: time.Since(unit.startedAt).Milliseconds(),
+		Reason:     reason,
+		By:         by,
+	})
+}
+
+func (s *Server) stopUnit(unit *fakeUnit, reason string) {
+	signal := "SIGTERM"
+
+	if len(unit.model.StopSignal) > 0 {
+		if parsed, err := daemon.ParseSignal(unit.model.StopSignal); err == nil {
+			signal = daemon.SignalName(parsed)
+		}
+	}
+
+	s.exitUnit(unit, -1, signal, reason, "clienttest")
+}
+
+func unitNotFound(unitID uint64) *pb.StopResponse {
+	return &pb.StopResponse{
+		UnitId: unitID,
+		Error:  fmt.Sprintf("unit %d not found", unitID),
+		Code:   uint32(codes.NotFound),
+	}
+}
+
+func unitNotRunning(unit *fakeUnit) (string, uint32) {
+	return fmt.Sprintf("unit %s (%d) is not running", unit.model.Name, unit.model.ID),
+		uint32(codes.FailedPrecondition)
+}
+
+// unitIDsExcept returns the ids of the units except the listed ones, s.mu
+// must be held
+func (s *Server) unitIDsExcept(except []uint64) []uint64 {
+	var unitIDs []uint64
+
+	for unitID := range s.units {
+		if !slices.Contains(except, unitID) {
+			unitIDs = append(unitIDs, unitID)
+		}
+	}
+
+	slices.Sort(unitIDs)
+	return unitIDs
+}
+
+// unitsAction runs the action for every unit and sends the results in the
+// order of the ids
+func (s *Server) unitsAction(
+	unitIDs []uint64,
+	stream pb.ProcessService_StopServer,
+	action func(unit *fakeUnit, response *pb.StopResponse),
+) error {
+	s.mu.Lock()
+	responses := make([]*pb.StopResponse, len(unitIDs))
+
+	for i, unitID := range unitIDs {
+		unit := s.units[unitID]
+
+		if unit == nil {
+			responses[i] = unitNotFound(unitID)
+			continue
+		}
+
+		responses[i] = &pb.StopResponse{UnitId: unitID}
+		action(unit, responses[i])
+
+		if responses[i].Unit == nil {
+			responses[i].Unit = unit.pb()
+		}
+	}
+
+	s.mu.Unlock()
+
+	for _, response := range responses {
+		if err := stream.Send(response); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// service serves the RPCs for the server, it keeps them out of the Server
+// methods
+type service struct {
+	pb.UnimplementedProcessServiceServer
+	s *Server
+}
+
+func (f *service) List(ctx context.Context, request *emptypb.Empty) (*pb.ListResponse, error) {
+	s := f.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	response := &pb.ListResponse{}
+
+	for _, unit := range s.units {
+		response.Units = append(response.Units, unit.pb())
+	}
+
+	return response, nil
+}
+
+func (f *service) Start(ctx context.Context, request *pb.StartRequest) (*pb.StartResponse, error) {
+	model, err := daemon.UnitModelFromStartRequest(request)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	s := f.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lastID++
+	model.ID = s.lastID
+	model.OwnerUID = 0
+	model.RestartsCount = 0
+
+	unit := &fakeUnit{model: model}
+	s.units[model.ID] = unit
+	s.startUnit(unit)
+
+	return &pb.StartResponse{Id: model.ID, Pid: unit.pid, Name: model.Name}, nil
+}
+
+func (f *service) Show(ctx context.Context, request *pb.ShowRequest) (*pb.ShowResponse, error) {
+	s := f.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unit := s.units[request.UnitId]
+
+	if unit == nil {
+		return nil, status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
+	}
+
+	model := &unit.model
+	response := &pb.ShowResponse{
+		Id:       model.ID,
+		Name:     model.Name,
+		Cwd:      model.CWD,
+		Command:  strings.Join(append([]string{model.Bin}, model.Args...), " "),
+		Env:      daemon.MaskEnv(model.Env),
+		User:     model.User,
+		Group:    model.Group,
+		Groups:   model.Groups,
+		OwnerUid: model.OwnerUID,
+
+		RestartPolicy:  model.RestartPolicy,
+		RestartDelayMs: model.RestartDelay.Milliseconds(),
+		StopSignal:     model.StopSignal,
+		StopTimeoutMs:  model.StopTimeout.Milliseconds(),
+
+		Health:           unit.pb().Health,
+		HealthCmd:        model.HealthCmd,
+		HealthIntervalMs: model.HealthInterval.Milliseconds(),
+		Hooks:            make([]string, len(model.Hooks)),
+		EnvMode:          model.EnvMode,
+		EnvAllow:         model.EnvAllow,
+		EnvFiles:         model.EnvFiles,
+		Labels:           model.Labels,
+		Stdin:            model.Stdin,
+		Tty:              model.TTY,
+		StripAnsi:        model.StripANSI,
+	}
+
+	for i, hook := range model.Hooks {
+		response.Hooks[i] = hook.String()
+	}
+
+	// the latest events first, like the daemon
+	for i := len(unit.history) - 1; i >= 0 && len(response.History) < showHistoryEvents; i-- {
+		response.History = append(response.History, unit.history[i])
+	}
+
+	return response, nil
+}
+
+func (f *service) Update(ctx context.Context, request *pb.UpdateRequst) (*pb.UpdateResponse, error) {
+	s := f.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unit := s.units[request.UnitId]
+
+	if unit == nil {
+		return nil, status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
+	}
+
+	updatedModel, changes, err := daemon.UpdateUnitModel(unit.model, request)
+
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	unit.model = updatedModel
+	s.publish(daemon.EventUpdated, unit, nil)
+
+	if request.Restart && len(changes) > 0 && unit.status == daemon.UnitStatusRunning {
+		s.stopUnit(unit, "update requested")
+		unit.model.RestartsCount += 1
+		s.startUnit(unit)
+	}
+
+	return &pb.UpdateResponse{Name: updatedModel.Name, Changes: changes, Unit: unit.pb()}, nil
+}
+
+func (f *service) StartExisting(request *pb.StopRequest, stream pb.ProcessService_StartExistingServer) error {
+	s := f.s
+
+	return s.unitsAction(request.UnitIds, stream, func(unit *fakeUnit, response *pb.StopResponse) {
+		if unit.status == daemon.UnitStatusRunning {
+			response.Error = fmt.Sprintf("unit %s (%d) is already running", unit.model.Name, unit.model.ID)
+			response.Code = uint32(codes.FailedPrecondition)
+			return
+		}
+
+		s.startUnit(unit)
+	})
+}
+
+func (f *service) stop(unitIDs []uint64, stream pb.ProcessService_StopServer) error {
+	s := f.s
+
+	return s.unitsAction(unitIDs, stream, func(unit *fakeUnit, response *pb.StopResponse) {
+		if unit.status != daemon.UnitStatusRunning {
+			response.Error, response.Code = unitNotRunning(unit)
+			return
+		}
+
+		s.stopUnit(unit, "stop requested")
+	})
+}
+
+func (f *service) Stop(request *pb.StopRequest, stream pb.ProcessService_StopServer) error {
+	return f.stop(request.UnitIds, stream)
+}
+
+func (f *service) StopAll(request *pb.ExceptRequest, stream pb.ProcessService_StopAllServer) error {
+	return f.stop(f.unitIDsExcept(request.UnitIds), stream)
+}
+
+func (f *service) restart(unitIDs []uint64, stream pb.ProcessService_RestartServer) error {
+	s := f.s
+
+	return s.unitsAction(unitIDs, stream, func(unit *fakeUnit, response *pb.StopResponse) {
+		if unit.status == daemon.UnitStatusRunning {
+			s.stopUnit(unit, "restart requested")
+		}
+
+		unit.model.RestartsCount += 1
+		s.startUnit(unit)
+	})
+}
+
+func (f *service) Restart(request *pb.StopRequest, stream pb.ProcessService_RestartServer) error {
+	return f.restart(request.UnitIds, stream)
+}
+
+func (f *service) RestartAll(request *pb.ExceptRequest, stream pb.ProcessService_RestartAllServer) error {
+	return f.restart(f.unitIDsExcept(request.UnitIds), stream)
+}
+
+func (f *service) delete(unitIDs []uint64, stream pb.ProcessService_DeleteServer) error {
+	s := f.s
+
+	return s.unitsAction(unitIDs, stream, func(unit *fakeUnit, response *pb.StopResponse) {
+		if unit.status == daemon.UnitStatusRunning {
+			s.stopUnit(unit, "delete requested")
+		}
+
+		delete(s.units, unit.model.ID)
+		s.publish(daemon.EventDeleted, unit, nil)
+	})
+}
+
+func (f *service) Delete(request *pb.StopRequest, stream pb.ProcessService_DeleteServer) error {
+	return f.delete(request.UnitIds, stream)
+}
+
+func (f *service) DeleteAll(request *pb.ExceptRequest, stream pb.ProcessService_DeleteAllServer) error {
+	return f.delete(f.unitIDsExcept(request.UnitIds), stream)
+}
+
+func (f *service) signal(
+	unitIDs []uint64,
+	signalName string,
+	stream pb.ProcessService_SignalServer,
+) error {
+	signal, err := daemon.ParseSignal(signalName)
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return f.s.unitsAction(unitIDs, stream, func(unit *fakeUnit, response *pb.StopResponse) {
+		if unit.status != daemon.UnitStatusRunning {
+			response.Error, response.Code = unitNotRunning(unit)
+			return
+		}
+
+		unit.signals = append(unit.signals, daemon.SignalName(signal))
+	})
+}
+
+func (f *service) Signal(request *pb.SignalRequest, stream pb.ProcessService_SignalServer) error {
+	return f.signal(request.UnitIds, request.Signal, stream)
+}
+
+func (f *service) SignalAll(request *pb.SignalAllRequest, stream pb.ProcessService_SignalAllServer) error {
+	return f.signal(f.unitIDsExcept(request.Except), request.Signal, stream)
+}
+
+func (f *service) unitIDsExcept(except []uint64) []uint64 {
+	f.s.mu.Lock()
+	defer f.s.mu.Unlock()
+	return f.s.unitIDsExcept(except)
+}
+
+func (f *service) Send(ctx context.Context, request *pb.SendRequest) (*emptypb.Empty, error) {
+	s := f.s
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	unit := s.units[request.UnitId]
+
+	if unit == nil {
+		return nil, status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
+	}
+
+	if !unit.model.Stdin && !unit.model.TTY {
+		return nil, status.Errorf(
+			codes.FailedPrecondition,
+			"unit %s (%d) has no stdin, enable it with pm0 update --stdin --restart %d",
+			unit.model.Name,
+			unit.model.ID,
+			unit.model.ID,
+		)
+	}
+
+	if unit.status != daemon.UnitStatusRunning {
+		message, _ := unitNotRunning(unit)
+		return nil, status.Error(codes.FailedPrecondition, message)
+	}
+
+	unit.stdin = append(unit.stdin, request.Data...)
+	return &emptypb.Empty{}, nil
+}
+
+func (f *service) Logs(request *pb.LogsRequest, stream pb.ProcessService_LogsServer) error {
+	s := f.s
+	s.mu.Lock()
+	unit := s.units[request.UnitId]
+
+	if unit == nil {
+		s.mu.Unlock()
+		return status.Errorf(codes.NotFound, "unit %d not found", request.UnitId)
+	}
+
+	// like the daemon, the last lines are sent from the last one
+	tailLines := min(uint64(len(unit.logs)), request.Lines)
+	lines := slices.Clone(unit.logs[uint64(len(unit.logs))-tailLines:])
+	slices.Reverse(lines)
+	sent := len(unit.logs)
+	changed := s.changed
+	s.mu.Unlock()
+
+	for _, line := range lines {
+		if err := stream.Send(&pb.LogsResponse{Line: line}); err != nil {
+			return err
+		}
+	}
+
+	if err := stream.Send(&pb.LogsResponse{Flush: true}); err != nil {
+		return err
+	}
+
+	if !request.Follow {
+		return nil
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		}
+
+		s.mu.Lock()
+		unit := s.units[request.UnitId]
+
+		if unit == nil {
+			s.mu.Unlock()
+			return nil
+		}
+
+		lines := slices.Clone(unit.logs[sent:])
+		sent = len(unit.logs)
+		changed = s.changed
+		s.mu.Unlock()
+
+		for _, line := range lines {
+			if err := stream.Send(&pb.LogsResponse{Line: line}); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (f *service) Events(request *pb.EventsRequest, stream pb.ProcessService_EventsServer) error {
+	s := f.s
+	next := request.Since
+
+	// like the daemon, the events are replayed only after a sequence number
+	s.mu.Lock()
+
+	if next == 0 {
+		next = s.lastEvent
+	}
+
+	lastEvent := s.lastEvent
+	s.mu.Unlock()
+
+	if next > lastEvent {
+		return status.Errorf(
+			codes.OutOfRange,
+			"sequence number %d is ahead of the daemon (%d), it was restarted",
+			next,
+			lastEvent,
+		)
+	}
+
+	for {
+		s.mu.Lock()
+		events := slices.Clone(s.events[min(next, uint64(len(s.events))):])
+		changed := s.changed
+		s.mu.Unlock()
+
+		for _, event := range events {
+			next = event.Seq
+
+			if event.Unit != nil && !daemon.UnitMatchesSelectors(event.Unit, request.Selectors) {
+				continue
+			}
+
+			if err := stream.Send(event); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-changed:
+		}
+	}
+}
+
+func (f *service) Wait(ctx context.Context, request *pb.WaitRequest) (*pb.WaitResponse, error) {
+	switch request.Condition {
+	case daemon.WaitRunning, daemon.WaitExited, daemon.WaitHealthy, daemon.WaitStopped:
+	default:
+		return nil, status.Errorf(
+			codes.InvalidArgument,
+			"unknown wait condition %q, expected running, exited, healthy or stopped",
+			request.Condition,
+		)
+	}
+
+	if len(request.UnitIds) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "specify the units to wait for")
+	}
+
+	var timeout <-chan time.Time
+
+	if request.TimeoutMs > 0 {
+		timer := time.NewTimer(time.Duration(request.TimeoutMs) * time.Millisecond)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	s := f.s
+
+	for {
+		s.mu.Lock()
+		response, pending, err := s.waitResults(request)
+		changed := s.changed
+		s.mu.Unlock()
+
+		if err != nil || len(pending) == 0 {
+			return response, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-timeout:
+			formatted := make([]string, len(pending))
+
+			for i, unitID := range pending {
+				formatted[i] = fmt.Sprint(unitID)
+			}
+
+			return nil, status.Errorf(
+				codes.DeadlineExceeded,
+				"timed out after %s waiting for units %s to be %s",
+				time.Duration(request.TimeoutMs)*time.Millisecond,
+				strings.Join(formatted, ", "),
+				request.Condition,
+			)
+		case <-changed:
+		}
+	}
+}
+
+// waitResults returns the results of the units and the ids of the ones that
+// don't meet the wait condition yet, s.mu must be held
+func (s *Server) waitResults(request *pb.WaitRequest) (*pb.WaitResponse, []uint64, error) {
+	response := &pb.WaitResponse{}
+	var pending []uint64
+
+	for _, unitID := range request.UnitIds {
+		unit := s.units[unitID]
+
+		if unit == nil {
+			return nil, nil, status.Errorf(codes.NotFound, "unit %d not found", unitID)
+		}
+
+		if request.Condition == daemon.WaitHealthy && len(unit.model.HealthCmd) == 0 {
+			return nil, nil, status.Errorf(
+				codes.FailedPrecondition,
+				"unit %s (%d) has no health command",
+				unit.model.Name,
+				unit.model.ID,
+			)
+		}
+
+		result := &pb.WaitResult{UnitId: unitID, Unit: unit.pb()}
+		var met bool
+
+		switch request.Condition {
+		case daemon.WaitRunning:
+			met = unit.status == daemon.UnitStatusRunning
+		case daemon.WaitHealthy:
+			met = unit.status == daemon.UnitStatusRunning && unit.health == daemon.UnitHealthHealthy.String()
+		case daemon.WaitStopped:
+			met = unit.status == daemon.UnitStatusStopped
+		case daemon.WaitExited:
+			met = unit.status != daemon.UnitStatusRunning
+			result.ExitCode = unit.exitCode
+			result.Signal = unit.exitSignal
+		}
+
+		if !met {
+			pending = append(pending, unitID)
+			continue
+		}
+
+		response.Results = append(response.Results, result)
+	}
+
+	slices.Sort(pending)
+	return response, pending, nil
+}
@@ -0,0 +1,43 @@
+// Package client is the Go client of the pm0 daemon.
+//
+// A Client connects to the daemon socket or to a TCP listener, with TLS and a
+// bearer token for remote daemons:
+//
+//	c, err := client.New(client.WithAddress("pm0.internal:7777"), client.WithTLS(nil), client.WithToken(token))
+//
+//	if err != nil {
+//		return err
+//	}
+//
+//	defer c.Close()
+//
+//	units, err := c.List(ctx)
+//
+// The streaming RPCs are iterators. Unit actions like Stop yield a result per
+// unit, the failure of a unit is its Err and doesn't end the iteration:
+//
+//	for result, err := range c.Stop(ctx, 1, 2) {
+//		if err != nil {
+//			return err
+//		}
+//
+//		if errors.Is(result.Err, client.ErrNotFound) {
+//			continue
+//		}
+//	}
+//
+// Errors of the daemon are *Error values with the gRPC status code, compare
+// them to ErrNotFound and the other sentinels with errors.Is.
+//
+// Package clienttest has an in-process fake daemon for unit tests.
+//
+// # Compatibility
+//
+// The exported API of this package and of clienttest is stable: within the
+// major version of the module identifiers are not removed or renamed and
+// signatures don't change. New methods, options, struct fields and constants
+// may be added, so don't rely on the positions of struct fields or implement
+// the package interfaces outside of it. The types are independent from the
+// daemon protocol, which is an implementation detail; a client works with the
+// daemons of the same and later minor versions.
+package client
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error is an error returned by the daemon, for a call or for one unit of a
+// unit action
+type Error struct {
+	Code    codes.Code
+	Message string
+	// UnitID is the unit the error is about, 0 for the errors of calls
+	UnitID uint64
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// GRPCStatus lets status.Code and status.Convert read the code
+func (e *Error) GRPCStatus() *status.Status {
+	return status.New(e.Code, e.Message)
+}
+
+// Is reports whether the target is a sentinel error with the same code
+func (e *Error) Is(target error) bool {
+	sentinel, ok := target.(*Error)
+	return ok && len(sentinel.Message) == 0 && sentinel.Code == e.Code
+}
+
+// The sentinel errors match the errors of the daemon by their code
+var (
+	ErrNotFound           = &Error{Code: codes.NotFound}
+	ErrInvalidArgument    = &Error{Code: codes.InvalidArgument}
+	ErrFailedPrecondition = &Error{Code: codes.FailedPrecondition}
+	ErrPermissionDenied   = &Error{Code: codes.PermissionDenied}
+	ErrUnauthenticated    = &Error{Code: codes.Unauthenticated}
+	ErrDeadlineExceeded   = &Error{Code: codes.DeadlineExceeded}
+	ErrOutOfRange         = &Error{Code: codes.OutOfRange}
+	ErrUnavailable        = &Error{Code: codes.Unavailable}
+)
+
+// convertError converts the errors of the grpc calls, context errors are
+// returned as they are
+func convertError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+
+	st, ok := status.FromError(err)
+
+	if !ok {
+		return err
+	}
+
+	return &Error{Code: st.Code(), Message: st.Message()}
+}
+
+// unitError converts the error of a unit in the results of unit actions
+func unitError(unitID uint64, code uint32, message string) error {
+	if len(message) == 0 {
+		return nil
+	}
+
+	// daemons before the codes of unit results only sent the message
+	if code == uint32(codes.OK) {
+		code = uint32(codes.Unknown)
+	}
+
+	return &Error{Code: codes.Code(code), Message: message, UnitID: unitID}
+}
+
+// UnitResults are the collected results of a unit action
+type UnitResults []UnitResult
+
+// Err joins the errors of the failed units, nil if none failed
+func (results UnitResults) Err() error {
+	errs := make([]error, len(results))
+
+	for i, result := range results {
+		errs[i] = result.Err
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var sentinels = []*Error{
+	ErrNotFound,
+	ErrInvalidArgument,
+	ErrFailedPrecondition,
+	ErrPermissionDenied,
+	ErrUnauthenticated,
+	ErrDeadlineExceeded,
+	ErrOutOfRange,
+	ErrUnavailable,
+}
+
+// expectSentinel checks that the error matches only the sentinel, nil
+// means none of them
+func expectSentinel(t *testing.T, err error, sentinel *Error) {
+	t.Helper()
+
+	for _, target := range sentinels {
+		if matched := errors.Is(err, target); matched != (target == sentinel) {
+			t.Fatalf("errors.Is(%v, %s) is %t", err, target.Code, matched)
+		}
+	}
+}
+
+func TestConvertError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		sentinel *Error
+		code     codes.Code
+		message  string
+	}{
+		{"not found", status.Error(codes.NotFound, "unit 3 not found"), ErrNotFound, codes.NotFound, "unit 3 not found"},
+		{"invalid argument", status.Error(codes.InvalidArgument, "bin can't be empty"), ErrInvalidArgument, codes.InvalidArgument, "bin can't be empty"},
+		{"failed precondition", status.Error(codes.FailedPrecondition, "unit web (3) is not running"), ErrFailedPrecondition, codes.FailedPrecondition, "unit web (3) is not running"},
+		{"permission denied", status.Error(codes.PermissionDenied, "the read role can't call Stop"), ErrPermissionDenied, codes.PermissionDenied, "the read role can't call Stop"},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "invalid token"), ErrUnauthenticated, codes.Unauthenticated, "invalid token"},
+		{"deadline exceeded", status.Error(codes.DeadlineExceeded, "timed out after 1s waiting for units 3 to be running"), ErrDeadlineExceeded, codes.DeadlineExceeded, "timed out after 1s waiting for units 3 to be running"},
+		{"out of range", status.Error(codes.OutOfRange, "sequence number 9 is ahead of the daemon (3), it was restarted"), ErrOutOfRange, codes.OutOfRange, "sequence number 9 is ahead of the daemon (3), it was restarted"},
+		{"unavailable", status.Error(codes.Unavailable, "connection refused"), ErrUnavailable, codes.Unavailable, "connection refused"},
+		{"internal", status.Error(codes.Internal, "database is closed"), nil, codes.Internal, "database is closed"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := convertError(context.Background(), test.err)
+
+			var clientErr *Error
+
+			if !errors.As(err, &clientErr) {
+				t.Fatalf("err %#v isn't an *Error", err)
+			}
+
+			if clientErr.Code != test.code || clientErr.Message != test.message || clientErr.UnitID != 0 {
+				t.Fatalf("unexpected error %#v", clientErr)
+			}
+
+			if err.Error() != test.message {
+				t.Fatalf("message %q, expected %q", err.Error(), test.message)
+			}
+
+			if code := status.Code(err); code != test.code {
+				t.Fatalf("status code %s, expected %s", code, test.code)
+			}
+
+			expectSentinel(t, err, test.sentinel)
+		})
+	}
+}
+
+func TestConvertErrorPassesOtherErrors(t *testing.T) {
+	if err := convertError(context.Background(), nil); err != nil {
+		t.Fatalf("converted nil to %v", err)
+	}
+
+	plainErr := errors.New("not a status")
+
+	if err := convertError(context.Background(), plainErr); err != plainErr {
+		t.Fatalf("converted a plain error to %#v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := convertError(ctx, status.Error(codes.Canceled, "context canceled"))
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err %#v, expected the context error", err)
+	}
+
+	expectSentinel(t, err, nil)
+}
+
+func TestUnitError(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     codes.Code
+		message  string
+		sentinel *Error
+		result   codes.Code
+	}{
+		{"not found", codes.NotFound, "unit 3 not found", ErrNotFound, codes.NotFound},
+		{"not running", codes.FailedPrecondition, "unit web (3) is not running", ErrFailedPrecondition, codes.FailedPrecondition},
+		{"permission denied", codes.PermissionDenied, "units of other users can't be stopped", ErrPermissionDenied, codes.PermissionDenied},
+		{"unknown", codes.Unknown, "exec: \"nope\": executable file not found in $PATH", nil, codes.Unknown},
+		{"daemon without codes", codes.OK, "unit 3 not found", nil, codes.Unknown},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := unitError(3, uint32(test.code), test.message)
+
+			var clientErr *Error
+
+			if !errors.As(err, &clientErr) {
+				t.Fatalf("err %#v isn't an *Error", err)
+			}
+
+			if clientErr.Code != test.result || clientErr.Message != test.message || clientErr.UnitID != 3 {
+				t.Fatalf("unexpected error %#v", clientErr)
+			}
+
+			expectSentinel(t, err, test.sentinel)
+		})
+	}
+
+	if err := unitError(3, uint32(codes.OK), ""); err != nil {
+		t.Fatalf("a unit without an error got %v", err)
+	}
+}
+
+func TestErrorIsOnlyMatchesSentinels(t *testing.T) {
+	err := &Error{Code: codes.NotFound, Message: "unit 3 not found"}
+	other := &Error{Code: codes.NotFound, Message: "unit 4 not found"}
+
+	if errors.Is(err, other) {
+		t.Fatal("errors with messages matched by their code")
+	}
+
+	if !errors.Is(err, err) {
+		t.Fatal("the error didn't match itself")
+	}
+
+	results := UnitResults{
+		{UnitID: 3, Err: err},
+		{UnitID: 4},
+		{UnitID: 5, Err: unitError(5, uint32(codes.FailedPrecondition), "unit db (5) is not running")},
+	}
+
+	joined := results.Err()
+
+	if !errors.Is(joined, ErrNotFound) || !errors.Is(joined, ErrFailedPrecondition) || errors.Is(joined, ErrInvalidArgument) {
+		t.Fatalf("unexpected joined error %v", joined)
+	}
+
+	if (UnitResults{{UnitID: 3}}).Err() != nil {
+		t.Fatal("results without errors have an error")
+	}
+}
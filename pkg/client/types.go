@@ -0,0 +1,381 @@
+package client
+
+import (
+	"time"
+
+	"github.com/TrixiS/pm0/internal/daemon/pb"
+)
+
+type UnitStatus uint32
+
+const (
+	StatusRunning UnitStatus = 0
+	StatusExited  UnitStatus = 1
+	StatusFailed  UnitStatus = 2
+	StatusStopped UnitStatus = 3
+)
+
+var unitStatusNames = map[UnitStatus]string{
+	StatusRunning: "running",
+	StatusExited:  "exited",
+	StatusFailed:  "failed",
+	StatusStopped: "stopped",
+}
+
+func (status UnitStatus) String() string {
+	if name, ok := unitStatusNames[status]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// The health of running units with a health command, it's empty until the
+// first check and for the other units
+const (
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+)
+
+// The types of the events
+const (
+	EventStarted        = "started"
+	EventExited         = "exited"
+	EventStopped        = "stopped"
+	EventRestarting     = "restarting"
+	EventHealth         = "health"
+	EventUpdated        = "updated"
+	EventDeleted        = "deleted"
+	EventDaemonStarted  = "daemon_started"
+	EventDaemonReloaded = "daemon_reloaded"
+	EventDaemonStopping = "daemon_stopping"
+)
+
+// The conditions of Wait
+const (
+	WaitRunning = "running"
+	WaitExited  = "exited"
+	WaitHealthy = "healthy"
+	WaitStopped = "stopped"
+)
+
+// Unit is the state of a unit
+type Unit struct {
+	ID       uint64
+	Name     string
+	PID      int // 0 unless the unit is running
+	Status   UnitStatus
+	Restarts uint32
+	// StartedAt is the start of the current or the last process
+	StartedAt time.Time
+	OwnerUID  uint32
+	Health    string
+	Labels    map[string]string
+}
+
+// UnitSpec describes the unit to start. The zero values of the policies
+// are the daemon defaults
+type UnitSpec struct {
+	Name   string
+	Bin    string
+	Args   []string
+	CWD    string
+	Env    []string // KEY=value, values can be @file: and @secret: references
+	User   string
+	Group  string
+	Groups []string
+
+	RestartPolicy string // on-failure, always or never
+	RestartDelay  time.Duration
+	StopSignal    string
+	StopTimeout   time.Duration
+
+	HealthCmd      string
+	HealthInterval time.Duration
+
+	Hooks    []string // phase=url or phase=!command
+	EnvMode  string   // none, daemon or allow
+	EnvAllow []string
+	EnvFiles []string
+	Labels   map[string]string
+
+	Stdin     bool
+	TTY       bool
+	StripANSI bool
+}
+
+// UnitDetails is the spec of a unit with the policies it runs with and its
+// latest events. Bin and Args of the spec are empty, Command is the command
+// line. Env values that look like secrets are masked
+type UnitDetails struct {
+	UnitSpec
+	ID       uint64
+	Command  string
+	OwnerUID uint32
+	Health   string
+	History  []UnitEvent
+}
+
+// UnitEvent is a recorded lifecycle event of a unit
+type UnitEvent struct {
+	ID       uint64
+	UnitID   uint64
+	Type     string
+	Time     time.Time
+	PID      int
+	ExitCode int
+	Signal   string
+	Duration time.Duration // run time of the process, set for exits
+	Reason   string
+	By       string // the caller who requested a stop or restart
+	Health   string
+	LogTail  []string // the last log lines of a failed unit
+}
+
+// Event is a unit lifecycle event or a daemon event
+type Event struct {
+	Seq       uint64 // resume after it with EventsOptions.Since
+	Time      time.Time
+	Type      string
+	Unit      *Unit      // snapshot of the unit, nil for daemon events
+	UnitEvent *UnitEvent // nil for the events that aren't recorded, like updated
+	Message   string
+}
+
+// UnitResult is the result of a unit action like Stop for one unit
+type UnitResult struct {
+	UnitID uint64
+	Unit   *Unit // nil if the unit wasn't found
+	Err    error // *Error when the action failed for the unit
+}
+
+// The update fields, the names of the UnitSpec fields in the daemon protocol
+const (
+	FieldName           = "name"
+	FieldBin            = "bin"
+	FieldArgs           = "args"
+	FieldCWD            = "cwd"
+	FieldEnv            = "env"
+	FieldEnvMode        = "env_mode"
+	FieldEnvAllow       = "env_allow"
+	FieldEnvFiles       = "env_files"
+	FieldLabels         = "labels"
+	FieldUser           = "user"
+	FieldGroup          = "group"
+	FieldGroups         = "groups"
+	FieldRestartPolicy  = "restart_policy"
+	FieldRestartDelay   = "restart_delay_ms"
+	FieldStopSignal     = "stop_signal"
+	FieldStopTimeout    = "stop_timeout_ms"
+	FieldHealthCmd      = "health_cmd"
+	FieldHealthInterval = "health_interval_ms"
+	FieldHooks          = "hooks"
+	FieldStdin          = "stdin"
+	FieldTTY            = "tty"
+	FieldStripANSI      = "strip_ansi"
+)
+
+// UpdateOptions sets the listed fields of the unit to the ones of Spec,
+// an empty value clears the field
+type UpdateOptions struct {
+	Spec   UnitSpec
+	Fields []string
+	// MergeEnv and MergeLabels merge the env and labels into the current
+	// ones, an empty value removes the key
+	MergeEnv    bool
+	MergeLabels bool
+	// Restart restarts the unit if it's running and anything changed
+	Restart bool
+}
+
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+type UpdateResult struct {
+	Changes    []FieldChange
+	Unit       Unit
+	RestartErr error // the unit was updated but failed to restart
+}
+
+type StartResult struct {
+	ID   uint64
+	Name string
+	PID  int
+}
+
+type WaitResult struct {
+	Unit     Unit
+	ExitCode int    // set for WaitExited
+	Signal   string // the signal that killed the unit, for WaitExited
+}
+
+func unitFromPB(unit *pb.Unit) Unit {
+	return Unit{
+		ID:        unit.Id,
+		Name:      unit.Name,
+		PID:       int(unit.Pid),
+		Status:    UnitStatus(unit.Status),
+		Restarts:  unit.RestartsCount,
+		StartedAt: time.Unix(unit.StartedAt, 0),
+		OwnerUID:  unit.OwnerUid,
+		Health:    unit.Health,
+		Labels:    unit.Labels,
+	}
+}
+
+func optionalUnitFromPB(unit *pb.Unit) *Unit {
+	if unit == nil {
+		return nil
+	}
+
+	converted := unitFromPB(unit)
+	return &converted
+}
+
+func unitEventFromPB(event *pb.UnitEvent) UnitEvent {
+	return UnitEvent{
+		ID:       event.Id,
+		UnitID:   event.UnitId,
+		Type:     event.Type,
+		Time:     time.UnixMilli(event.Time),
+		PID:      int(event.Pid),
+		ExitCode: int(event.ExitCode),
+		Signal:   event.Signal,
+		Duration: time.Duration(event.DurationMs) * time.Millisecond,
+		Reason:   event.Reason,
+		By:       event.By,
+		Health:   event.Health,
+		LogTail:  event.LogTail,
+	}
+}
+
+func eventFromPB(event *pb.Event) Event {
+	converted := Event{
+		Seq:     event.Seq,
+		Time:    time.UnixMilli(event.Time),
+		Type:    event.Type,
+		Unit:    optionalUnitFromPB(event.Unit),
+		Message: event.Message,
+	}
+
+	if event.UnitEvent != nil {
+		unitEvent := unitEventFromPB(event.UnitEvent)
+		converted.UnitEvent = &unitEvent
+	}
+
+	return converted
+}
+
+func unitDetailsFromPB(response *pb.ShowResponse) *UnitDetails {
+	details := &UnitDetails{
+		UnitSpec: UnitSpec{
+			Name:   response.Name,
+			CWD:    response.Cwd,
+			Env:    response.Env,
+			User:   response.User,
+			Group:  response.Group,
+			Groups: response.Groups,
+
+			RestartPolicy: response.RestartPolicy,
+			RestartDelay:  time.Duration(response.RestartDelayMs) * time.Millisecond,
+			StopSignal:    response.StopSignal,
+			StopTimeout:   time.Duration(response.StopTimeoutMs) * time.Millisecond,
+
+			HealthCmd:      response.HealthCmd,
+			HealthInterval: time.Duration(response.HealthIntervalMs) * time.Millisecond,
+
+			Hooks:    response.Hooks,
+			EnvMode:  response.EnvMode,
+			EnvAllow: response.EnvAllow,
+			EnvFiles: response.EnvFiles,
+			Labels:   response.Labels,
+
+			Stdin:     response.Stdin,
+			TTY:       response.Tty,
+			StripANSI: response.StripAnsi,
+		},
+		ID:       response.Id,
+		Command:  response.Command,
+		OwnerUID: response.OwnerUid,
+		Health:   response.Health,
+		History:  make([]UnitEvent, len(response.History)),
+	}
+
+	for i, event := range response.History {
+		details.History[i] = unitEventFromPB(event)
+	}
+
+	return details
+}
+
+func startRequestFromSpec(spec *UnitSpec) *pb.StartRequest {
+	return &pb.StartRequest{
+		Name:   spec.Name,
+		Bin:    spec.Bin,
+		Args:   spec.Args,
+		Cwd:    spec.CWD,
+		Env:    spec.Env,
+		User:   spec.User,
+		Group:  spec.Group,
+		Groups: spec.Groups,
+
+		RestartPolicy:  spec.RestartPolicy,
+		RestartDelayMs: spec.RestartDelay.Milliseconds(),
+		StopSignal:     spec.StopSignal,
+		StopTimeoutMs:  spec.StopTimeout.Milliseconds(),
+
+		HealthCmd:        spec.HealthCmd,
+		HealthIntervalMs: spec.HealthInterval.Milliseconds(),
+
+		Hooks:    spec.Hooks,
+		EnvMode:  spec.EnvMode,
+		EnvAllow: spec.EnvAllow,
+		EnvFiles: spec.EnvFiles,
+		Labels:   spec.Labels,
+
+		Stdin:     spec.Stdin,
+		Tty:       spec.TTY,
+		StripAnsi: spec.StripANSI,
+	}
+}
+
+func updateRequestFromOptions(unitID uint64, options *UpdateOptions) *pb.UpdateRequst {
+	spec := &options.Spec
+
+	return &pb.UpdateRequst{
+		UnitId: unitID,
+		Name:   spec.Name,
+		Bin:    spec.Bin,
+		Args:   spec.Args,
+		Cwd:    spec.CWD,
+		Env:    spec.Env,
+		User:   spec.User,
+		Group:  spec.Group,
+		Groups: spec.Groups,
+
+		RestartPolicy:  spec.RestartPolicy,
+		RestartDelayMs: spec.RestartDelay.Milliseconds(),
+		StopSignal:     spec.StopSignal,
+		StopTimeoutMs:  spec.StopTimeout.Milliseconds(),
+
+		HealthCmd:        spec.HealthCmd,
+		HealthIntervalMs: spec.HealthInterval.Milliseconds(),
+
+		Hooks:    spec.Hooks,
+		EnvMode:  spec.EnvMode,
+		EnvAllow: spec.EnvAllow,
+		EnvFiles: spec.EnvFiles,
+		Labels:   spec.Labels,
+
+		Stdin:     spec.Stdin,
+		Tty:       spec.TTY,
+		StripAnsi: spec.StripANSI,
+
+		MergeEnv:    options.MergeEnv,
+		MergeLabels: options.MergeLabels,
+		Restart:     options.Restart,
+	}
+}